@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/jkeresman01/tsm/session"
+	"github.com/jkeresman01/tsm/tmux"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			command is a non-interactive tsm subcommand.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			commands maps subcommand names to their implementation.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+var commands = map[string]command{
+	"last":    {usage: "tsm last", run: jumpCommand(session.Last)},
+	"back":    {usage: "tsm back", run: jumpCommand(session.Back)},
+	"forward": {usage: "tsm forward", run: jumpCommand(session.Forward)},
+	"mark":    {usage: "tsm mark <1-9>", run: markCommand},
+	"popup":   {usage: "tsm popup", run: popupCommand},
+	"switch":  {usage: "tsm switch [-d] [-r] [-g] <session>", run: switchCommand},
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ParseFlags parses the global flags that precede a subcommand.
+//
+//		@Description	-L selects a server by socket name and -S by socket path, like tmux
+//
+//		@Param			args		[]string		Command line arguments without the program name
+//		@Param			defaults	tmux.Server		Server from the configuration
+//
+//		@Return			[]string		Remaining arguments
+//		@Return			tmux.Server		Selected server
+//		@Return			error			Error for unknown or malformed flags
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func ParseFlags(args []string, defaults tmux.Server) ([]string, tmux.Server, error) {
+	fs := flag.NewFlagSet("tsm", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	name := fs.String("L", "", "tmux socket name")
+	path := fs.String("S", "", "tmux socket path")
+	if err := fs.Parse(args); err != nil {
+		return nil, defaults, err
+	}
+	server := defaults
+	if *name != "" {
+		server = tmux.Server{SocketName: *name}
+	}
+	if *path != "" {
+		server = tmux.Server{SocketPath: *path}
+	}
+	return fs.Args(), server, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Run executes a subcommand instead of starting the TUI.
+//
+//		@Param			args	[]string	Command line arguments without the program name
+//
+//		@Return			bool	True if args named a subcommand
+//		@Return			error	Error returned by the subcommand
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Run(args []string) (bool, error) {
+	if len(args) == 0 {
+		return false, nil
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return true, fmt.Errorf("unknown command %q", args[0])
+	}
+	if err := cmd.run(args[1:]); err != nil {
+		return true, fmt.Errorf("%s: %w", cmd.usage, err)
+	}
+	return true, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			jumpCommand adapts a jump list function to a subcommand.
+//
+//		@Param			jump	func() (string, error)	Jump to perform
+//
+//		@Return			func([]string) error	Subcommand implementation
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func jumpCommand(jump func() (string, error)) func([]string) error {
+	return func(args []string) error {
+		if len(args) > 0 {
+			return fmt.Errorf("unexpected arguments %v", args)
+		}
+		_, err := jump()
+		return err
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			switchCommand attaches to a session by name.
+//
+//		@Description	-d detaches the session's other clients, -r attaches read-only
+//		@Description	(outside tmux only) and -g opens a grouped view with its own current window
+//
+//		@Param			args	[]string	Flags followed by the session name
+//
+//		@Return			error	Error for bad arguments, a missing session or a failed attach
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func switchCommand(args []string) error {
+	fs := flag.NewFlagSet("switch", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var opts tmux.AttachOptions
+	fs.BoolVar(&opts.DetachOthers, "d", false, "detach other clients")
+	fs.BoolVar(&opts.ReadOnly, "r", false, "attach read-only")
+	fs.BoolVar(&opts.Grouped, "g", false, "attach a grouped view")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expected a session name")
+	}
+	name := fs.Arg(0)
+	if !tmux.HasSession(name) {
+		return fmt.Errorf("no session %q", name)
+	}
+	return session.AttachWith(name, opts)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			popupCommand opens the tsm TUI in a tmux popup.
+//
+//		@Description	The popup runs this executable against the same server
+//
+//		@Param			args	[]string	No arguments are accepted
+//
+//		@Return			error	Error if tmux is too old or tsm runs outside tmux
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func popupCommand(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments %v", args)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	return tmux.Popup(append([]string{exe}, tmux.CurrentServer().Flags()...)...)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			markCommand attaches to the session pinned to a mark slot.
+//
+//		@Param			args	[]string	Slot number
+//
+//		@Return			error	Error for a missing or invalid slot
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func markCommand(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected a slot number")
+	}
+	slot, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid slot %q", args[0])
+	}
+	_, err = session.OpenMark(slot)
+	return err
+}
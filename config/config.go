@@ -7,9 +7,18 @@ import (
 )
 
 type Config struct {
-	SearchPaths []string `json:"search_paths"`
-	MaxDepth    int      `json:"max_depth"`
-	Theme       string   `json:"theme"`
+	SearchPaths []string               `json:"search_paths"`
+	MaxDepth    int                    `json:"max_depth"`
+	Theme       string                 `json:"theme"`
+	Icons       string                 `json:"icons,omitempty"`
+	Themes      map[string]ThemeConfig `json:"themes,omitempty"`
+	Sources     []SourceConfig         `json:"sources,omitempty"`
+	GroupByRoot bool                   `json:"group_by_root,omitempty"`
+	SocketName  string                 `json:"socket_name,omitempty"`
+	SocketPath  string                 `json:"socket_path,omitempty"`
+	Env         map[string]string      `json:"env,omitempty"`
+	EnvFiles    []string               `json:"env_files,omitempty"`
+	Commands    map[string]string      `json:"commands,omitempty"`
 }
 
 func DefaultConfig() Config {
@@ -22,6 +31,7 @@ func DefaultConfig() Config {
 		},
 		MaxDepth: 3,
 		Theme:    "dark",
+		Icons:    "auto",
 	}
 }
 
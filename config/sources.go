@@ -0,0 +1,16 @@
+package config
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SourceConfig describes an extra directory source for create mode.
+//
+//		@Description	Type is "zoxide", "autojump", "fasd" or "command"
+//		@Description	Scores of the source are multiplied by Weight (1 when unset)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type SourceConfig struct {
+	Type    string  `json:"type"`
+	Command string  `json:"command,omitempty"`
+	Path    string  `json:"path,omitempty"`
+	Weight  float64 `json:"weight,omitempty"`
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ThemeConfig describes a user-defined theme.
+//
+//		@Description	Empty fields are inherited from the Base theme (dark when unset)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type ThemeConfig struct {
+	Base    string        `json:"base,omitempty"`
+	Palette PaletteConfig `json:"palette"`
+	Border  string        `json:"border,omitempty"`
+	Sizes   SizesConfig   `json:"sizes"`
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			PaletteConfig holds theme colors as ANSI numbers or hex strings.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type PaletteConfig struct {
+	Border        string `json:"border,omitempty"`
+	Secondary     string `json:"secondary,omitempty"`
+	DimBackground string `json:"dim_background,omitempty"`
+	Accent        string `json:"accent,omitempty"`
+	Highlight     string `json:"highlight,omitempty"`
+	Match         string `json:"match,omitempty"`
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			SizesConfig holds theme dimensions, zero values are inherited.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type SizesConfig struct {
+	LeftPanel       int `json:"left_panel,omitempty"`
+	RightPanel      int `json:"right_panel,omitempty"`
+	ContainerHeight int `json:"container_height,omitempty"`
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ThemesDir returns the directory holding user theme files.
+//
+//		@Return			string	Path to ~/.config/tsm/themes
+//		@Return			error	Error if home directory cannot be determined
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func ThemesDir() (string, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "themes"), nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			LoadThemes collects user-defined themes.
+//
+//		@Description	Reads every *.json file in ThemesDir, named after the file
+//		@Description	Themes defined in the config file override files of the same name
+//		@Description	Unreadable or malformed theme files are skipped
+//
+//		@Param			cfg		Config	Loaded configuration
+//
+//		@Return			map[string]ThemeConfig	Themes keyed by lowercase name
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func LoadThemes(cfg Config) map[string]ThemeConfig {
+	themes := make(map[string]ThemeConfig)
+
+	if dir, err := ThemesDir(); err == nil {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+		for _, file := range files {
+			if theme, err := loadThemeFile(file); err == nil {
+				name := strings.TrimSuffix(filepath.Base(file), ".json")
+				themes[strings.ToLower(name)] = theme
+			}
+		}
+	}
+
+	for name, theme := range cfg.Themes {
+		themes[strings.ToLower(name)] = theme
+	}
+
+	return themes
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			loadThemeFile parses a single theme file.
+//
+//		@Param			path	string	Path to the theme file
+//
+//		@Return			ThemeConfig	Parsed theme
+//		@Return			error		Error if file cannot be read or parsed
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func loadThemeFile(path string) (ThemeConfig, error) {
+	var theme ThemeConfig
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return theme, err
+	}
+	err = json.Unmarshal(data, &theme)
+	return theme, err
+}
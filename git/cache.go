@@ -0,0 +1,86 @@
+package git
+
+import "sync"
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Cache stores computed statuses per directory.
+//
+//		@Description	Safe for concurrent use, tracks in-flight computations to avoid duplicates
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			cacheEntry is the cached state of a single directory.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type cacheEntry struct {
+	status  Status
+	pending bool
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			NewCache creates an empty status cache.
+//
+//		@Return			*Cache	Initialized cache
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func NewCache() *Cache {
+	return &Cache{entries: make(map[string]cacheEntry)}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Get returns the cached status of a directory.
+//
+//		@Param			dir		string	Directory path
+//
+//		@Return			Status	Cached status
+//		@Return			bool	False if the status is missing or still being computed
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (c *Cache) Get(dir string) (Status, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[dir]
+	return e.status, ok && !e.pending
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Claim reserves a directory for computation.
+//
+//		@Param			dir		string	Directory path
+//
+//		@Return			bool	True if the caller should compute the status
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (c *Cache) Claim(dir string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[dir]; ok {
+		return false
+	}
+	c.entries[dir] = cacheEntry{pending: true}
+	return true
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Store records the computed status of a directory.
+//
+//		@Param			dir		string	Directory path
+//		@Param			status	Status	Computed status
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (c *Cache) Store(dir string, status Status) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[dir] = cacheEntry{status: status}
+}
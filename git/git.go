@@ -0,0 +1,223 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			Status describes the state of a git working tree.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Status struct {
+	IsRepo bool   // Whether the directory is inside a git working tree
+	Branch string // Current branch, or short commit hash when detached
+	Dirty  bool   // Whether there are uncommitted or untracked changes
+	Ahead  int    // Commits ahead of upstream
+	Behind int    // Commits behind upstream
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			GitDir locates the git directory of the working tree containing dir.
+//
+//		@Description	Walks up parent directories until a .git entry is found
+//		@Description	Follows "gitdir:" files used by worktrees and submodules
+//
+//		@Param			dir		string	Directory inside a working tree
+//
+//		@Return			string	Path to the git directory
+//		@Return			bool	False if dir is not inside a working tree
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func GitDir(dir string) (string, bool) {
+	for {
+		if gitDir, ok := resolveDotGit(dir); ok {
+			return gitDir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			resolveDotGit resolves the .git entry of a single directory.
+//
+//		@Param			dir		string	Candidate working tree root
+//
+//		@Return			string	Path to the git directory
+//		@Return			bool	False if dir has no usable .git entry
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func resolveDotGit(dir string) (string, bool) {
+	dotGit := filepath.Join(dir, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", false
+	}
+	if info.IsDir() {
+		return dotGit, true
+	}
+
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return "", false
+	}
+	target, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return "", false
+	}
+	target = strings.TrimSpace(target)
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(dir, target)
+	}
+	return target, true
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ReadHead returns the checked out branch by reading HEAD directly.
+//
+//		@Description	Cheap enough to call for every row, no git process is spawned
+//
+//		@Param			dir		string	Directory inside a working tree
+//
+//		@Return			string	Branch name, or short commit hash when detached
+//		@Return			bool	False if dir is not inside a working tree
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func ReadHead(dir string) (string, bool) {
+	gitDir, ok := GitDir(dir)
+	if !ok {
+		return "", false
+	}
+	data, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return "", false
+	}
+	head := strings.TrimSpace(string(data))
+	if ref, ok := strings.CutPrefix(head, "ref: "); ok {
+		return strings.TrimPrefix(ref, "refs/heads/"), true
+	}
+	if len(head) > 7 {
+		head = head[:7]
+	}
+	return head, true
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			GetStatus computes branch, dirty state and upstream distance.
+//
+//		@Description	Reads HEAD first and only invokes 'git status' for repositories
+//
+//		@Param			dir		string	Directory inside a working tree
+//
+//		@Return			Status	Working tree status (IsRepo false for plain directories)
+//		@Return			error	Error if git fails on a repository
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func GetStatus(dir string) (Status, error) {
+	branch, ok := ReadHead(dir)
+	if !ok {
+		return Status{}, nil
+	}
+	st := Status{IsRepo: true, Branch: branch}
+
+	out, err := run(dir, "status", "--porcelain=v2", "--branch")
+	if err != nil {
+		return st, err
+	}
+	parseStatus(out, &st)
+	return st, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			LastCommit returns the committer time of HEAD.
+//
+//		@Description	Returns the zero time for plain directories and empty repositories
+//
+//		@Param			dir		string	Directory inside a working tree
+//
+//		@Return			time.Time	Time of the last commit
+//		@Return			error		Error if git fails on a repository
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func LastCommit(dir string) (time.Time, error) {
+	if _, ok := GitDir(dir); !ok {
+		return time.Time{}, nil
+	}
+	out, err := run(dir, "log", "-1", "--format=%ct")
+	if err != nil {
+		return time.Time{}, err
+	}
+	secs, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+	if err != nil {
+		return time.Time{}, nil
+	}
+	return time.Unix(secs, 0), nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			parseStatus fills a Status from 'git status --porcelain=v2 --branch'.
+//
+//		@Param			out		string	Command output
+//		@Param			st		*Status	Status to update
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func parseStatus(out string, st *Status) {
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "# branch.ab "):
+			fields := strings.Fields(strings.TrimPrefix(line, "# branch.ab "))
+			if len(fields) == 2 {
+				st.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[0], "+"))
+				st.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "-"))
+			}
+		case strings.HasPrefix(line, "#"):
+		default:
+			st.Dirty = true
+		}
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			run executes a git command inside a directory.
+//
+//		@Param			dir		string		Directory to run in
+//		@Param			args	...string	git arguments
+//
+//		@Return			string	Standard output
+//		@Return			error	Error if git fails, carrying the last line git printed to stderr
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func run(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			lines := strings.Split(msg, "\n")
+			return "", errors.New(lines[len(lines)-1])
+		}
+		return "", err
+	}
+	return out.String(), nil
+}
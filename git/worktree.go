@@ -0,0 +1,165 @@
+package git
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			Worktree describes a working tree attached to a repository.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Worktree struct {
+	Path     string // Absolute path of the working tree
+	Head     string // Checked out commit
+	Branch   string // Checked out branch without refs/heads/, empty when detached
+	Bare     bool   // Whether this is the bare repository entry
+	Detached bool   // Whether HEAD is detached
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Label returns the branch name, or the short commit for detached worktrees.
+//
+//		@Return			string	Display label
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (w Worktree) Label() string {
+	if w.Branch != "" {
+		return w.Branch
+	}
+	if len(w.Head) > 7 {
+		return w.Head[:7]
+	}
+	return w.Head
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			RepoName returns the repository name of a main worktree or bare entry.
+//
+//		@Return			string	Directory name without a ".git" suffix
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (w Worktree) RepoName() string {
+	return strings.TrimSuffix(filepath.Base(w.Path), ".git")
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ListWorktrees lists the worktrees of the repository containing dir.
+//
+//		@Description	Executes 'git worktree list --porcelain', the main worktree comes first
+//
+//		@Param			dir		string	Directory inside the repository
+//
+//		@Return			[]Worktree	Worktrees of the repository
+//		@Return			error		Error if git fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func ListWorktrees(dir string) ([]Worktree, error) {
+	out, err := run(dir, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	return parseWorktrees(out), nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			parseWorktrees parses 'git worktree list --porcelain' output.
+//
+//		@Param			out		string	Command output, one blank-line separated block per worktree
+//
+//		@Return			[]Worktree	Parsed worktrees
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func parseWorktrees(out string) []Worktree {
+	var worktrees []Worktree
+	var cur *Worktree
+	for _, line := range strings.Split(out, "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "worktree":
+			worktrees = append(worktrees, Worktree{Path: value})
+			cur = &worktrees[len(worktrees)-1]
+		case "HEAD":
+			if cur != nil {
+				cur.Head = value
+			}
+		case "branch":
+			if cur != nil {
+				cur.Branch = strings.TrimPrefix(value, "refs/heads/")
+			}
+		case "bare":
+			if cur != nil {
+				cur.Bare = true
+			}
+		case "detached":
+			if cur != nil {
+				cur.Detached = true
+			}
+		}
+	}
+	return worktrees
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			AddWorktree checks out a branch into a new worktree.
+//
+//		@Description	Existing local or remote branches are checked out as-is,
+//		@Description	otherwise a new branch is created from HEAD. Other failures, such as
+//		@Description	an existing path, are returned as reported by git
+//
+//		@Param			dir		string	Directory inside the repository
+//		@Param			path	string	Path of the new worktree
+//		@Param			branch	string	Branch to check out or create
+//
+//		@Return			error	Error if git fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func AddWorktree(dir, path, branch string) error {
+	if branchExists(dir, branch) {
+		_, err := run(dir, "worktree", "add", path, branch)
+		return err
+	}
+	_, err := run(dir, "worktree", "add", "-b", branch, path)
+	return err
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			branchExists reports whether a local or remote-tracking branch exists.
+//
+//		@Param			dir		string	Directory inside the repository
+//		@Param			branch	string	Branch name without refs/heads/
+//
+//		@Return			bool	True if 'git worktree add' can check the branch out
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func branchExists(dir, branch string) bool {
+	if _, err := run(dir, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		return true
+	}
+	out, err := run(dir, "for-each-ref", "--format=%(refname)", "refs/remotes/*/"+branch)
+	return err == nil && strings.TrimSpace(out) != ""
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			WorktreePath proposes a location for a new worktree.
+//
+//		@Description	Sibling of the main worktree named "<repo>@<branch>" with "/" replaced by "-"
+//
+//		@Param			mainPath	string	Path of the main worktree or bare repository
+//		@Param			branch		string	Branch name
+//
+//		@Return			string	Proposed worktree path
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func WorktreePath(mainPath, branch string) string {
+	name := Worktree{Path: mainPath}.RepoName() + "@" + strings.ReplaceAll(branch, "/", "-")
+	return filepath.Join(filepath.Dir(mainPath), name)
+}
@@ -0,0 +1,87 @@
+package git
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseWorktrees(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want []Worktree
+	}{
+		{
+			name: "main and linked worktrees",
+			out: "worktree /src/app\nHEAD 1111111111111111111111111111111111111111\nbranch refs/heads/main\n\n" +
+				"worktree /src/app@feature-x\nHEAD 2222222222222222222222222222222222222222\nbranch refs/heads/feature/x\n\n",
+			want: []Worktree{
+				{Path: "/src/app", Head: "1111111111111111111111111111111111111111", Branch: "main"},
+				{Path: "/src/app@feature-x", Head: "2222222222222222222222222222222222222222", Branch: "feature/x"},
+			},
+		},
+		{
+			name: "bare repository and detached worktree",
+			out: "worktree /src/app.git\nbare\n\n" +
+				"worktree /src/app@fix\nHEAD 3333333333333333333333333333333333333333\ndetached\n\n",
+			want: []Worktree{
+				{Path: "/src/app.git", Bare: true},
+				{Path: "/src/app@fix", Head: "3333333333333333333333333333333333333333", Detached: true},
+			},
+		},
+		{
+			name: "path with spaces and extra attributes",
+			out:  "worktree /src/my app\nHEAD 4444444444444444444444444444444444444444\nbranch refs/heads/main\nlocked reason\nprunable gitdir file points to non-existent location\n",
+			want: []Worktree{
+				{Path: "/src/my app", Head: "4444444444444444444444444444444444444444", Branch: "main"},
+			},
+		},
+		{
+			name: "attributes before any worktree are ignored",
+			out:  "HEAD 5555555555555555555555555555555555555555\nbranch refs/heads/main\n",
+			want: nil,
+		},
+		{
+			name: "empty output",
+			out:  "",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseWorktrees(tt.out); !slices.Equal(got, tt.want) {
+				t.Errorf("parseWorktrees = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorktreeLabel(t *testing.T) {
+	tests := []struct {
+		w    Worktree
+		want string
+	}{
+		{Worktree{Branch: "feature/x", Head: "1234567890"}, "feature/x"},
+		{Worktree{Head: "1234567890", Detached: true}, "1234567"},
+		{Worktree{Head: "12345"}, "12345"},
+	}
+	for _, tt := range tests {
+		if got := tt.w.Label(); got != tt.want {
+			t.Errorf("%+v.Label() = %q, want %q", tt.w, got, tt.want)
+		}
+	}
+}
+
+func TestWorktreePath(t *testing.T) {
+	tests := []struct {
+		main, branch, want string
+	}{
+		{"/src/app", "feature/x", "/src/app@feature-x"},
+		{"/src/app.git", "main", "/src/app@main"},
+	}
+	for _, tt := range tests {
+		if got := WorktreePath(tt.main, tt.branch); got != tt.want {
+			t.Errorf("WorktreePath(%q, %q) = %q, want %q", tt.main, tt.branch, got, tt.want)
+		}
+	}
+}
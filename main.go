@@ -1,11 +1,18 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/jkeresman01/tsm/cli"
 	"github.com/jkeresman01/tsm/config"
 	"github.com/jkeresman01/tsm/logger_factory"
+	"github.com/jkeresman01/tsm/session"
 	"github.com/jkeresman01/tsm/styles"
+	"github.com/jkeresman01/tsm/tmux"
+	"github.com/jkeresman01/tsm/utils"
 	"github.com/jkeresman01/tsm/view"
 )
 
@@ -15,9 +22,14 @@ import (
 //
 //		@Description	Loads configuration from ~/.config/tsm/config.json
 //		@Description	Creates default config if none exists
-//		@Description	Initializes UI theme based on configuration
+//		@Description	Initializes UI theme based on configuration and user theme files
+//		@Description	Selects the icon set (Nerd Font, Unicode or ASCII)
 //		@Description	Sets up logging to tsm.log
-//		@Description	Starts the Bubble Tea TUI program
+//		@Description	Selects the tmux server from -L/-S or socket_name/socket_path
+//		@Description	Sets the environment of new sessions from env and env files
+//		@Description	Runs a subcommand such as 'tsm last' instead of the TUI when given
+//		@Description	Starts the Bubble Tea TUI program with mouse support
+//		@Description	Outside tmux, replaces itself with 'tmux attach-session' after the TUI exits
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func main() {
@@ -27,13 +39,41 @@ func main() {
 		cfg = config.DefaultConfig()
 	}
 
-	styles.InitTheme(cfg.Theme)
+	args, server, err := cli.ParseFlags(os.Args[1:], tmux.Server{
+		SocketName: cfg.SocketName,
+		SocketPath: utils.ExpandHome(cfg.SocketPath),
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "tsm:", err)
+		os.Exit(2)
+	}
+	tmux.SetServer(server)
+	session.Configure(cfg.Env, cfg.EnvFiles, cfg.Commands)
+
+	if handled, err := cli.Run(args); handled {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "tsm:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	styles.InitTheme(cfg.Theme, config.LoadThemes(cfg))
+	styles.InitIcons(cfg.Icons)
 
 	log := logger_factory.GetLogger("tsm.log")
 
-	p := tea.NewProgram(view.NewTsmManager(cfg), tea.WithAltScreen())
+	tmux.DeferAttach()
+	p := tea.NewProgram(view.NewTsmManager(cfg, log), tea.WithAltScreen(), tea.WithMouseCellMotion())
 
-	if err := p.Start(); err != nil {
+	if _, err := p.Run(); err != nil {
 		log.Fatal("TSM exited with error:", err)
 	}
+
+	if name, opts, ok := tmux.PendingAttach(); ok {
+		if err := tmux.ExecAttach(name, opts); err != nil {
+			fmt.Fprintln(os.Stderr, "tsm:", err)
+			os.Exit(1)
+		}
+	}
 }
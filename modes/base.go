@@ -2,6 +2,9 @@ package modes
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jkeresman01/tsm/tmux"
+	"github.com/jkeresman01/tsm/view/model"
 )
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -12,6 +15,15 @@ import (
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 type ModeStrategy interface {
+	/////////////////////////////////////////////////////////////////////////////////////////////
+	//
+	//  @Brief			Init returns the command to run when the mode becomes active.
+	//
+	//	@Return			tea.Cmd			Optional command (e.g., background loading)
+	//
+	/////////////////////////////////////////////////////////////////////////////////////////////
+	Init() tea.Cmd
+
 	/////////////////////////////////////////////////////////////////////////////////////////////
 	//
 	//  @Brief			Update processes a message and returns the next mode state and optional command.
@@ -62,7 +74,7 @@ type ModeStrategy interface {
 	//
 	//  @Brief			GetIcon returns the icon to display for this mode.
 	//
-	//	@Return			string	Nerd font icon
+	//	@Return			string	Icon from the active icon set
 	//
 	/////////////////////////////////////////////////////////////////////////////////////////////
 	GetIcon() string
@@ -75,4 +87,50 @@ type ModeStrategy interface {
 	//
 	/////////////////////////////////////////////////////////////////////////////////////////////
 	GetFooterText() string
+
+	/////////////////////////////////////////////////////////////////////////////////////////////
+	//
+	//  @Brief			GetShortcuts returns the keyboard shortcuts specific to this mode.
+	//
+	//	@Description	Global shortcuts are not included, the help dialog lists them separately
+	//
+	//	@Return			[]model.Shortcut	Mode shortcuts in their current state
+	//
+	/////////////////////////////////////////////////////////////////////////////////////////////
+	GetShortcuts() []model.Shortcut
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			InputCapturer is implemented by modes that temporarily own the keyboard.
+//
+//		@Description	While CapturesInput is true, single-key global shortcuts (q, ?, Tab)
+//		@Description	are delivered to the mode as text instead of being handled globally
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type InputCapturer interface {
+	CapturesInput() bool
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Sorter is implemented by modes whose list order can be changed.
+//
+//		@Description	The label is shown in the header next to the mode indicator
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Sorter interface {
+	SortLabel() string
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SessionsChangedMsg reports that tmux sessions or windows changed.
+//
+//		@Description	Sent by the manager for control-mode notifications while tsm is open
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type SessionsChangedMsg struct {
+	Sessions []string   // Current session names
+	Event    tmux.Event // Notification that triggered the refresh
 }
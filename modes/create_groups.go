@@ -0,0 +1,286 @@
+package modes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jkeresman01/tsm/styles"
+	"github.com/jkeresman01/tsm/utils"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			createRow is a line of the create mode list.
+//
+//		@Description	Rows without a directory are group headers
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type createRow struct {
+	dir   string // Directory, empty for group headers
+	root  string // Search root the row belongs to, empty for directories outside all roots
+	count int    // Number of directories in the group (headers only)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			header reports whether the row is a group header.
+//
+//		@Return			bool	True for headers
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (r createRow) header() bool { return r.dir == "" }
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			createLayoutState holds the create mode list layout.
+//
+//		@Description	Kept at package level so the layout survives switching modes
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type createLayoutState struct {
+	roots     []string        // Expanded search roots in config order
+	grouped   bool            // Whether directories are grouped under their root
+	collapsed map[string]bool // Collapsed groups by root
+	root      string          // Root the list is restricted to, empty for all roots
+}
+
+var createLayout = createLayoutState{collapsed: make(map[string]bool)}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SetSearchRoots configures the roots create mode groups directories by.
+//
+//		@Param			paths	[]string	Search paths from the configuration
+//		@Param			grouped	bool		Whether to start with grouping enabled
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func SetSearchRoots(paths []string, grouped bool) {
+	createLayout.roots = nil
+	for _, p := range paths {
+		createLayout.roots = append(createLayout.roots, filepath.Clean(utils.ExpandHome(p)))
+	}
+	createLayout.grouped = grouped
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			rootOf returns the deepest search root containing a directory.
+//
+//		@Param			dir		string	Directory path
+//
+//		@Return			string	Search root, empty if the directory is outside all roots
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func rootOf(dir string) string {
+	best := ""
+	for _, root := range createLayout.roots {
+		if isUnder(dir, root) && len(root) > len(best) {
+			best = root
+		}
+	}
+	return best
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			isUnder reports whether dir is inside root.
+//
+//		@Param			dir		string	Directory path
+//		@Param			root	string	Search root
+//
+//		@Return			bool	True if dir is root or one of its descendants
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func isUnder(dir, root string) bool {
+	return dir == root || strings.HasPrefix(dir, root+string(filepath.Separator))
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			filterRoot keeps the directories of the root the list is restricted to.
+//
+//		@Param			dirs	[]string	Directories to filter
+//
+//		@Return			[]string	Directories under the selected root, all without one
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func filterRoot(dirs []string) []string {
+	if createLayout.root == "" {
+		return dirs
+	}
+	out := make([]string, 0, len(dirs))
+	for _, d := range dirs {
+		if isUnder(d, createLayout.root) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			buildRows lays out directories as list rows.
+//
+//		@Description	When grouped, each root gets a header followed by its directories
+//		@Description	Collapsed groups only show their header unless expandAll is set
+//
+//		@Param			dirs		[]string	Filtered directories in display order
+//		@Param			expandAll	bool		Ignore collapsed groups (while searching)
+//
+//		@Return			[]createRow	Rows to display
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func buildRows(dirs []string, expandAll bool) []createRow {
+	rows := make([]createRow, 0, len(dirs))
+	if !createLayout.grouped {
+		for _, d := range dirs {
+			rows = append(rows, createRow{dir: d, root: rootOf(d)})
+		}
+		return rows
+	}
+
+	groups := make(map[string][]string)
+	for _, d := range dirs {
+		root := rootOf(d)
+		groups[root] = append(groups[root], d)
+	}
+	for _, root := range append(slices.Clone(createLayout.roots), "") {
+		members := groups[root]
+		if len(members) == 0 {
+			continue
+		}
+		delete(groups, root)
+		rows = append(rows, createRow{root: root, count: len(members)})
+		if createLayout.collapsed[root] && !expandAll {
+			continue
+		}
+		for _, d := range members {
+			rows = append(rows, createRow{dir: d, root: root})
+		}
+	}
+	return rows
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			rowLabel returns the text shown for a directory row.
+//
+//		@Description	Grouped rows show the path relative to the root's parent, e.g. work/team/api
+//
+//		@Param			r	createRow	Directory row
+//
+//		@Return			string	Basename, relative path or home-relative path
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func rowLabel(r createRow) string {
+	if !createLayout.grouped {
+		return filepath.Base(r.dir)
+	}
+	if r.root == "" {
+		return tildePath(r.dir)
+	}
+	rel, err := filepath.Rel(filepath.Dir(r.root), r.dir)
+	if err != nil {
+		return r.dir
+	}
+	return rel
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			dirLabel returns the text shown for a directory, which search matches.
+//
+//		@Param			dir		string	Absolute directory path
+//
+//		@Return			string	Label as rendered by rowLabel
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func dirLabel(dir string) string {
+	return rowLabel(createRow{dir: dir, root: rootOf(dir)})
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			tildePath shortens a path inside the home directory with "~".
+//
+//		@Param			path	string	Absolute path
+//
+//		@Return			string	Path starting with "~" when possible
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func tildePath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || !isUnder(path, home) {
+		return path
+	}
+	return "~" + strings.TrimPrefix(path, home)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			rootName returns the display name of a search root.
+//
+//		@Param			root	string	Search root, empty for directories outside all roots
+//
+//		@Return			string	Home-relative root or "other"
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func rootName(root string) string {
+	if root == "" {
+		return "other"
+	}
+	return tildePath(root)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			renderGroupHeader renders a collapsible group header.
+//
+//		@Param			r			createRow	Header row
+//		@Param			selected	bool		Whether the cursor is on the header
+//
+//		@Return			string	Header line with fold icon, root and directory count
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func renderGroupHeader(r createRow, selected bool) string {
+	icon := styles.CurrentIcons.Expanded
+	if createLayout.collapsed[r.root] {
+		icon = styles.CurrentIcons.Collapsed
+	}
+	prefix := "  "
+	if selected {
+		prefix = styles.CurrentIcons.Pointer + " "
+	}
+	title := lipgloss.NewStyle().Bold(true).Foreground(styles.CurrentTheme.AccentColor).Render(icon + " " + rootName(r.root))
+	count := lipgloss.NewStyle().Foreground(styles.CurrentTheme.SecondaryColor).Render(fmt.Sprintf(" (%d)", r.count))
+	return prefix + title + count + "\n"
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			nextRoot returns the root following the current restriction.
+//
+//		@Description	Cycles all roots -> first root -> ... -> last root -> all roots
+//
+//		@Return			string	Next root, empty for all roots
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func nextRoot() string {
+	roots := createLayout.roots
+	for i, root := range roots {
+		if root == createLayout.root {
+			if i+1 < len(roots) {
+				return roots[i+1]
+			}
+			return ""
+		}
+	}
+	if len(roots) == 0 {
+		return ""
+	}
+	return roots[0]
+}
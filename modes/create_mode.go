@@ -3,13 +3,24 @@ package modes
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
+	"github.com/jkeresman01/tsm/session"
+	"github.com/jkeresman01/tsm/state"
+	"github.com/jkeresman01/tsm/styles"
 	"github.com/jkeresman01/tsm/tmux"
 	"github.com/jkeresman01/tsm/utils"
+	"github.com/jkeresman01/tsm/view/model"
+)
+
+const (
+	createListTop  = 2 // Lines above the first directory row (search bar)
+	createReserved = 6 // Search bar, selected path line, count footer and status line
 )
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -22,8 +33,23 @@ import (
 type CreateMode struct {
 	dirs     []string
 	filtered []string
+	rows     []createRow // Visible list lines: directories and group headers
 	cursor   int
 	input    textinput.Model
+	view     viewport
+	clicks   clickTracker
+	wt       *worktreeView      // Worktree sub-list, nil when showing directories
+	favs     state.Favorites    // Favorite directories, listed first
+	split    int                // First row after the favorites section, -1 for none
+	tags     state.Tags         // Directory tags shown as chips
+	tagInput textinput.Model    // Tag editor of the selected directory
+	tagging  bool               // Whether the tag editor is open
+	cmdInput textinput.Model    // One-off startup command of the new session
+	running  bool               // Whether the command prompt is open
+	source   []string           // Directories in ranked order
+	sortBy   string             // Active sort order, one of dirSorts
+	frecency map[string]float64 // Visit scores from the sessions created by tsm
+	status   string             // Last error, shown below the count
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -36,11 +62,23 @@ type CreateMode struct {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func NewCreateMode(dirs []string) *CreateMode {
-	return &CreateMode{
-		dirs:     dirs,
-		filtered: dirs,
+	favs, _ := state.LoadFavorites()
+	tags, _ := state.LoadTags()
+	prefs, _ := state.LoadPrefs()
+	visits, _ := state.LoadDirVisits()
+	m := &CreateMode{
+		source:   dirs,
 		input:    newSearchInput(),
+		favs:     favs,
+		tags:     tags,
+		tagInput: newTagInput(),
+		cmdInput: newCommandInput(),
+		sortBy:   validSort(dirSorts, prefs.DirSort),
+		frecency: visits.Frecency(),
 	}
+	m.dirs = sortDirs(dirs, m.sortBy, m.frecency)
+	m.applyFilter()
+	return m
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -54,15 +92,44 @@ func NewCreateMode(dirs []string) *CreateMode {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *CreateMode) Update(msg tea.Msg) (ModeStrategy, tea.Cmd) {
-	if key, ok := msg.(tea.KeyMsg); ok {
-		if next, done := m.handleKey(key); done {
-			return next, nil
+	if m.wt != nil {
+		return m.updateWorktrees(msg)
+	}
+	if m.tagging {
+		return m.updateTagPrompt(msg)
+	}
+	if m.running {
+		return m.updateCommandPrompt(msg)
+	}
+	switch t := msg.(type) {
+	case tea.KeyMsg:
+		if next, cmd, done := m.handleKey(t); done {
+			return next, cmd
 		}
+	case tea.MouseMsg:
+		return m.handleMouse(t), m.fetchVisibleGit()
+	case gitStatusMsg:
+		return m, nil
+	case commitTimesMsg:
+		m.resort()
+		return m, m.fetchVisibleGit()
 	}
 	cmd := m.updateQuery(msg)
 	m.applyFilter()
 	m.clampCursor()
-	return m, cmd
+	return m, tea.Batch(cmd, m.fetchVisibleGit())
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Init starts loading git statuses for the initially visible directories.
+//
+//		@Return			tea.Cmd	Background git status command
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) Init() tea.Cmd {
+	m.clampCursor()
+	return tea.Batch(m.fetchVisibleGit(), m.fetchSortData())
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -73,13 +140,25 @@ func (m *CreateMode) Update(msg tea.Msg) (ModeStrategy, tea.Cmd) {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *CreateMode) View() string {
+	if m.wt != nil {
+		return m.renderWorktrees()
+	}
 	var b strings.Builder
 	b.WriteString(m.renderSearchBar())
 	if len(m.filtered) == 0 {
 		return b.String() + m.renderEmptyState()
 	}
 	m.renderDirectoryList(&b)
+	if m.tagging {
+		b.WriteString("\n  Tags: " + m.tagInput.View())
+		return b.String()
+	}
+	if m.running {
+		b.WriteString("\n  Command: " + m.cmdInput.View())
+		return b.String()
+	}
 	b.WriteString(m.renderCount())
+	b.WriteString(m.renderStatus())
 	return b.String()
 }
 
@@ -97,7 +176,23 @@ func (m *CreateMode) ModeName() string { return "CREATE" }
 //	@Brief			Reset clears the mode state.
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func (m *CreateMode) Reset() {}
+func (m *CreateMode) Reset() {
+	m.closeWorktrees()
+	m.closeTagPrompt()
+	m.closeCommandPrompt()
+	m.status = ""
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			CapturesInput reports whether a prompt owns the keyboard.
+//
+//		@Return			bool	True while tags, a command or a branch name is being typed
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) CapturesInput() bool {
+	return m.tagging || m.running || (m.wt != nil && m.wt.adding)
+}
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
@@ -131,30 +226,127 @@ func newSearchInput() textinput.Model {
 //		@Param			k		tea.KeyMsg		Keyboard message
 //
 //		@Return			ModeStrategy	Next mode (if changed)
+//		@Return			tea.Cmd			Command to execute
 //		@Return			bool			Whether key was handled
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func (m *CreateMode) handleKey(k tea.KeyMsg) (ModeStrategy, bool) {
+func (m *CreateMode) handleKey(k tea.KeyMsg) (ModeStrategy, tea.Cmd, bool) {
+	if slot, ok := slotKey(k.String(), "alt+"); ok {
+		m.pinSelected(slot)
+		return m, nil, true
+	}
 	switch k.String() {
 	case "up", "k":
 		m.moveCursor(-1)
 	case "down", "j":
 		m.moveCursor(1)
 	case "enter":
-		return m.confirmSelection(), true
+		return m.activate(), nil, true
+	case "alt+enter":
+		m.startCommandPrompt()
+		return m, nil, true
+	case "alt+g":
+		m.toggleGrouping()
+		return m, nil, true
+	case "alt+c":
+		m.toggleCollapse()
+		return m, nil, true
+	case "alt+r":
+		m.cycleRoot()
+		return m, nil, true
+	case "alt+s":
+		return m, m.cycleSort(), true
+	case "ctrl+w":
+		m.openWorktrees()
+		return m, nil, true
+	case "ctrl+f":
+		m.toggleFavorite()
+		return m, nil, true
+	case "ctrl+t":
+		m.startTagPrompt()
+		return m, nil, true
 	}
-	return nil, false
+	return nil, nil, false
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			handleMouse processes mouse input.
+//
+//		@Description	Wheel moves the cursor, click selects, double-click creates the session
+//
+//		@Param			msg		tea.MouseMsg	Mouse event relative to the mode's view
+//
+//		@Return			ModeStrategy	Next mode
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) handleMouse(msg tea.MouseMsg) ModeStrategy {
+	if delta := wheelDelta(msg); delta != 0 {
+		m.moveCursor(delta)
+		return m
+	}
+	if !isLeftClick(msg) {
+		return m
+	}
+	row, ok := m.rowAtLine(msg.Y - createListTop)
+	if !ok {
+		return m
+	}
+	m.cursor = row
+	m.clampCursor()
+	if m.clicks.click(row) {
+		return m.activate()
+	}
+	return m
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			rowAtLine maps a rendered list line to a row index.
+//
+//		@Description	Accounts for the extra path line under the selected directory and the
+//		@Description	favorites separator
+//
+//		@Param			line	int		Line relative to the first rendered row
+//
+//		@Return			int		Row index
+//		@Return			bool	False if the line holds no row
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) rowAtLine(line int) (int, bool) {
+	if line < 0 {
+		return 0, false
+	}
+	for i := m.view.offset; i < len(m.rows); i++ {
+		if i == m.split {
+			if line == 0 {
+				return 0, false
+			}
+			line--
+		}
+		if line == 0 {
+			return i, true
+		}
+		line--
+		if i == m.cursor && m.hasSelection() {
+			if line == 0 {
+				return i, true
+			}
+			line--
+		}
+	}
+	return 0, false
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	 @Brief			GetIcon returns the mode's icon.
 //
-//		@Return			string	Nerd font icon
+//		@Return			string	Icon from the active icon set
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *CreateMode) GetIcon() string {
-	return "󰐕"
+	return styles.CurrentIcons.Create
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -165,9 +357,59 @@ func (m *CreateMode) GetIcon() string {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *CreateMode) GetFooterText() string {
+	if m.wt != nil && m.wt.adding {
+		return "type branch • ↵ create worktree • ⎋ cancel"
+	}
+	if m.tagging {
+		return "type tags • ↵ save • ⎋ cancel"
+	}
+	if m.running {
+		return "type command • ↵ create • ⎋ cancel"
+	}
+	if m.wt != nil {
+		return "↑↓ navigate • ↵ open worktree • a add worktree • ⎋ back • q quit"
+	}
 	return "↑↓ navigate • ↵ create • ⇥ cycle • ? help • q quit"
 }
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			GetShortcuts returns the shortcuts for the help dialog.
+//
+//		@Return			[]model.Shortcut	Create mode shortcuts
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) GetShortcuts() []model.Shortcut {
+	if m.wt != nil {
+		return []model.Shortcut{
+			{Key: "↑ / k", Desc: "Move up"},
+			{Key: "↓ / j", Desc: "Move down"},
+			{Key: "Enter", Desc: "Create repo@branch session for worktree"},
+			{Key: "a", Desc: "Add worktree from branch name"},
+			{Key: "Esc", Desc: "Back to directories"},
+		}
+	}
+	return []model.Shortcut{
+		{Key: "↑ / k", Desc: "Move up"},
+		{Key: "↓ / j", Desc: "Move down"},
+		{Key: "Enter", Desc: "Create session from directory"},
+		{Key: "Alt+Enter", Desc: "Create session running a one-off command"},
+		{Key: "Click / Wheel", Desc: "Select directory"},
+		{Key: "Double-click", Desc: "Create session from clicked directory"},
+		{Key: "Ctrl+W", Desc: "List worktrees of selected repository"},
+		{Key: "Alt+1-9", Desc: "Pin directory to mark slot"},
+		{Key: "Ctrl+F", Desc: "Toggle favorite"},
+		{Key: "*query", Desc: "Search favorites only"},
+		{Key: "Ctrl+T", Desc: "Edit directory tags"},
+		{Key: "#tag", Desc: "Search directories with tag"},
+		{Key: "Alt+G", Desc: "Group directories by search path"},
+		{Key: "Alt+C / Enter", Desc: "Collapse or expand group"},
+		{Key: "Alt+R", Desc: "Restrict to next search path"},
+		{Key: "Alt+S", Desc: "Cycle sort: frecency, name, mtime, last commit"},
+		{Key: "type", Desc: "Search directories"},
+	}
+}
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	 @Brief			updateQuery updates the search input field.
@@ -189,7 +431,178 @@ func (m *CreateMode) updateQuery(msg tea.Msg) tea.Cmd {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *CreateMode) applyFilter() {
-	m.filtered = utils.FuzzyFilter(m.dirs, m.query())
+	q, only := favoritesQuery(m.query())
+	q, tags := tagQuery(q)
+	matches := filterTagged(utils.FuzzyFilterBy(m.dirs, q, dirLabel), m.tags.Dir, tags)
+	m.filtered = favoritesFirst(filterRoot(matches), m.favs.IsDir, only)
+	m.rows = buildRows(m.filtered, m.query() != "")
+	m.split = -1
+	if !createLayout.grouped {
+		m.split = favoritesSplit(len(m.rows), func(i int) bool { return m.favs.IsDir(m.rows[i].dir) })
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			startTagPrompt opens the tag editor for the selected directory.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) startTagPrompt() {
+	if !m.hasSelection() {
+		return
+	}
+	m.tagging = true
+	m.tagInput.SetValue(strings.Join(m.tags.Dir(m.selectedDir()), " "))
+	m.tagInput.CursorEnd()
+	m.tagInput.Focus()
+	m.input.Blur()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			updateTagPrompt processes input while the tag editor is open.
+//
+//		@Param			msg		tea.Msg		Input message
+//
+//		@Return			ModeStrategy	This mode
+//		@Return			tea.Cmd			Command from the input field
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) updateTagPrompt(msg tea.Msg) (ModeStrategy, tea.Cmd) {
+	if k, ok := msg.(tea.KeyMsg); ok {
+		switch k.String() {
+		case "enter":
+			m.tags.SetDir(m.selectedDir(), parseTagList(m.tagInput.Value()))
+			if err := state.SaveTags(m.tags); err != nil {
+				m.status = err.Error()
+			}
+			m.closeTagPrompt()
+			m.applyFilter()
+			m.clampCursor()
+			return m, nil
+		case "esc":
+			m.closeTagPrompt()
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.tagInput, cmd = m.tagInput.Update(msg)
+	return m, cmd
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			closeTagPrompt closes the tag editor and refocuses the search.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) closeTagPrompt() {
+	m.tagging = false
+	m.tagInput.Reset()
+	m.tagInput.Blur()
+	m.input.Focus()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			newCommandInput creates the input used to enter a startup command.
+//
+//		@Return			textinput.Model	Configured input field
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func newCommandInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "default shell"
+	ti.Prompt = ""
+	ti.CharLimit = 256
+	ti.Width = 40
+	return ti
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			startCommandPrompt asks for the command of a new session.
+//
+//		@Description	The prompt starts with the command configured for the directory
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) startCommandPrompt() {
+	if !m.hasSelection() {
+		return
+	}
+	m.running = true
+	m.cmdInput.SetValue(session.StartupCommand(m.selectedDir()))
+	m.cmdInput.CursorEnd()
+	m.cmdInput.Focus()
+	m.input.Blur()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			updateCommandPrompt processes input while the command prompt is open.
+//
+//		@Description	Enter creates the session with the typed command, an empty command
+//		@Description	starts the default shell
+//
+//		@Param			msg		tea.Msg		Input message
+//
+//		@Return			ModeStrategy	SwitchMode after creating, otherwise this mode
+//		@Return			tea.Cmd			Command from the input field
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) updateCommandPrompt(msg tea.Msg) (ModeStrategy, tea.Cmd) {
+	if k, ok := msg.(tea.KeyMsg); ok {
+		switch k.String() {
+		case "enter":
+			dir := m.selectedDir()
+			command := strings.TrimSpace(m.cmdInput.Value())
+			m.closeCommandPrompt()
+			if err := session.CreateWithCommand(filepath.Base(dir), dir, command); err != nil {
+				m.status = fmt.Sprintf("creating session %s failed: %v", filepath.Base(dir), err)
+				return m, nil
+			}
+			sessions, _ := tmux.ListSessions()
+			return NewSwitchMode(sessions), nil
+		case "esc":
+			m.closeCommandPrompt()
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.cmdInput, cmd = m.cmdInput.Update(msg)
+	return m, cmd
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			closeCommandPrompt closes the command prompt and refocuses the search.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) closeCommandPrompt() {
+	m.running = false
+	m.cmdInput.Reset()
+	m.cmdInput.Blur()
+	m.input.Focus()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			toggleFavorite flags or unflags the selected directory as favorite.
+//
+//		@Description	The cursor follows the directory as it moves between sections
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) toggleFavorite() {
+	if !m.hasSelection() {
+		return
+	}
+	dir := m.selectedDir()
+	m.favs.ToggleDir(dir)
+	if err := state.SaveFavorites(m.favs); err != nil {
+		m.status = err.Error()
+	}
+	m.applyFilter()
+	m.cursor = max(m.rowIndex(dir, ""), 0)
+	m.clampCursor()
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -200,7 +613,7 @@ func (m *CreateMode) applyFilter() {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *CreateMode) moveCursor(delta int) {
-	if len(m.filtered) == 0 {
+	if len(m.rows) == 0 {
 		m.cursor = 0
 		return
 	}
@@ -214,7 +627,7 @@ func (m *CreateMode) moveCursor(delta int) {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *CreateMode) clampCursor() {
-	n := len(m.filtered)
+	n := len(m.rows)
 	if n == 0 {
 		m.cursor = 0
 		return
@@ -224,13 +637,28 @@ func (m *CreateMode) clampCursor() {
 	} else if m.cursor >= n {
 		m.cursor = n - 1
 	}
+	m.view.follow(m.cursor, n, m.listHeight())
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			listHeight returns the number of directory rows that fit on screen.
+//
+//		@Return			int		Visible row count
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) listHeight() int {
+	if m.split >= 0 {
+		return bodyHeight(createReserved + 1)
+	}
+	return bodyHeight(createReserved)
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	 @Brief			confirmSelection creates a tmux session from the selected directory.
 //
-//		@Return			ModeStrategy	SwitchMode with updated session list
+//		@Return			ModeStrategy	SwitchMode with updated session list, this mode on error
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *CreateMode) confirmSelection() ModeStrategy {
@@ -239,11 +667,195 @@ func (m *CreateMode) confirmSelection() ModeStrategy {
 	}
 	dir := m.selectedDir()
 	name := filepath.Base(dir)
-	tmux.CreateSession(name, dir)
+	if err := session.Create(name, dir); err != nil {
+		m.status = fmt.Sprintf("creating session %s failed: %v", name, err)
+		return m
+	}
 	sessions, _ := tmux.ListSessions()
 	return NewSwitchMode(sessions)
 }
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			cycleSort switches to the next sort order and remembers it.
+//
+//		@Description	Shows the error in the status line if the order cannot be saved
+//
+//		@Return			tea.Cmd	Command loading commit times when sorting by commit
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) cycleSort() tea.Cmd {
+	m.sortBy = nextSort(dirSorts, m.sortBy)
+	if err := saveSortPref(func(p *state.Prefs) { p.DirSort = m.sortBy }); err != nil {
+		m.status = err.Error()
+	}
+	m.resort()
+	return m.fetchSortData()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			resort reapplies the sort order, keeping the selected directory.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) resort() {
+	dir := ""
+	if m.hasSelection() {
+		dir = m.selectedDir()
+	}
+	m.dirs = sortDirs(m.source, m.sortBy, m.frecency)
+	m.applyFilter()
+	if i := m.rowIndex(dir, ""); dir != "" && i >= 0 {
+		m.cursor = i
+	}
+	m.clampCursor()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SetDirs replaces the directories, keeping the query and the selection.
+//
+//		@Param			dirs	[]string	Directories in ranked order
+//
+//		@Return			tea.Cmd	Loaders of the git status and sort data of the new directories
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) SetDirs(dirs []string) tea.Cmd {
+	m.source = dirs
+	m.resort()
+	return tea.Batch(m.fetchVisibleGit(), m.fetchSortData())
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			fetchSortData loads data the active sort order needs.
+//
+//		@Return			tea.Cmd	Commit time loader when sorting by commit, nil otherwise
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) fetchSortData() tea.Cmd {
+	if m.sortBy != "commit" {
+		return nil
+	}
+	return fetchCommitTimes(missingCommitTimes(m.source))
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SortLabel returns the active sort order for the header.
+//
+//		@Return			string	Sort order name
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) SortLabel() string { return m.sortBy }
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			activate runs the action of the selected row.
+//
+//		@Description	Headers fold or unfold their group, directories create a session
+//
+//		@Return			ModeStrategy	Next mode
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) activate() ModeStrategy {
+	if m.cursor < len(m.rows) && m.rows[m.cursor].header() {
+		m.toggleCollapse()
+		return m
+	}
+	return m.confirmSelection()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			toggleGrouping switches between the flat and the grouped list.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) toggleGrouping() {
+	dir := ""
+	if m.hasSelection() {
+		dir = m.selectedDir()
+	}
+	createLayout.grouped = !createLayout.grouped
+	m.applyFilter()
+	m.cursor = 0
+	if dir != "" {
+		m.cursor = max(m.rowIndex(dir, ""), 0)
+	}
+	m.clampCursor()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			toggleCollapse folds or unfolds the group of the selected row.
+//
+//		@Description	The cursor moves onto the group header
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) toggleCollapse() {
+	if !createLayout.grouped || m.cursor >= len(m.rows) {
+		return
+	}
+	root := m.rows[m.cursor].root
+	createLayout.collapsed[root] = !createLayout.collapsed[root]
+	m.applyFilter()
+	m.cursor = max(m.rowIndex("", root), 0)
+	m.clampCursor()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			cycleRoot restricts the list to the next search root.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) cycleRoot() {
+	createLayout.root = nextRoot()
+	m.applyFilter()
+	m.cursor = 0
+	m.clampCursor()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			rowIndex finds a directory row, or the header of a root when dir is empty.
+//
+//		@Param			dir		string	Directory to find
+//		@Param			root	string	Root whose header to find when dir is empty
+//
+//		@Return			int		Row index, -1 if not visible
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) rowIndex(dir, root string) int {
+	return slices.IndexFunc(m.rows, func(r createRow) bool {
+		if dir == "" {
+			return r.header() && r.root == root
+		}
+		return r.dir == dir
+	})
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			pinSelected pins the selected directory to a mark slot.
+//
+//		@Description	The session is created from the directory when the mark is opened
+//
+//		@Param			slot	int		Slot number from 1 to state.MaxMarks
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) pinSelected(slot int) {
+	if !m.hasSelection() {
+		return
+	}
+	dir := m.selectedDir()
+	name := tmux.SanitizeSessionName(filepath.Base(dir))
+	if err := session.PinMark(slot, state.Mark{Name: name, Path: dir}); err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.status = fmt.Sprintf("Pinned %s to mark %d", name, slot)
+}
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	 @Brief			hasSelection returns whether a valid directory is selected.
@@ -252,7 +864,7 @@ func (m *CreateMode) confirmSelection() ModeStrategy {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *CreateMode) hasSelection() bool {
-	return len(m.filtered) > 0 && m.cursor >= 0 && m.cursor < len(m.filtered)
+	return m.cursor >= 0 && m.cursor < len(m.rows) && !m.rows[m.cursor].header()
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -263,7 +875,7 @@ func (m *CreateMode) hasSelection() bool {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *CreateMode) selectedDir() string {
-	return m.filtered[m.cursor]
+	return m.rows[m.cursor].dir
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -272,12 +884,12 @@ func (m *CreateMode) selectedDir() string {
 //
 //		@Param			i		int		Row index
 //
-//		@Return			string	Prefix ("▶ " for selected, "  " for others)
+//		@Return			string	Prefix (pointer icon for selected, "  " for others)
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *CreateMode) rowPrefix(i int) string {
 	if i == m.cursor {
-		return "▶ "
+		return styles.CurrentIcons.Pointer + " "
 	}
 	return "  "
 }
@@ -301,7 +913,7 @@ func (m *CreateMode) query() string {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *CreateMode) renderSearchBar() string {
-	return "🔍 " + m.input.View() + "\n\n"
+	return styles.CurrentIcons.Search + " " + m.input.View() + "\n\n"
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -323,9 +935,18 @@ func (m *CreateMode) renderEmptyState() string {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *CreateMode) renderDirectoryList(b *strings.Builder) {
-	q := m.query()
-	for i, d := range m.filtered {
-		m.renderDirectoryRow(b, i, d, q)
+	q, _ := favoritesQuery(m.query())
+	q, _ = tagQuery(q)
+	start, end := m.view.bounds(len(m.rows), m.listHeight())
+	for i := start; i < end; i++ {
+		if i == m.split {
+			b.WriteString(renderFavoritesSeparator())
+		}
+		if r := m.rows[i]; r.header() {
+			b.WriteString(renderGroupHeader(r, i == m.cursor))
+		} else {
+			m.renderDirectoryRow(b, i, r, q)
+		}
 	}
 }
 
@@ -335,25 +956,61 @@ func (m *CreateMode) renderDirectoryList(b *strings.Builder) {
 //
 //		@Param			b	*strings.Builder	String builder to append to
 //		@Param			i	int					Row index
-//		@Param			d	string				Directory path
+//		@Param			r	createRow			Directory row
 //		@Param			q	string				Search query for highlighting
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func (m *CreateMode) renderDirectoryRow(b *strings.Builder, i int, d, q string) {
-	icon := "󰉋 "
+func (m *CreateMode) renderDirectoryRow(b *strings.Builder, i int, r createRow, q string) {
+	d := r.dir
+	icon := styles.CurrentIcons.Folder + " "
 	prefix := m.rowPrefix(i)
 	b.WriteString(prefix)
+	b.WriteString(favoritePrefix(m.favs.IsDir(d)))
 	b.WriteString(icon)
-	b.WriteString(utils.HighlightMatches(filepath.Base(d), q))
+	b.WriteString(utils.HighlightMatches(rowLabel(r), q))
+	b.WriteString(renderGitStatus(d))
+	b.WriteString(renderTagChips(m.tags.Dir(d)))
 	if i == m.cursor {
-		b.WriteString("  󰄾")
+		b.WriteString("  " + styles.CurrentIcons.Chevron)
 	}
 	b.WriteByte('\n')
 	if i == m.cursor {
-		b.WriteString("    󰉖 " + d + "\n")
+		b.WriteString("    " + styles.CurrentIcons.FolderPath + " " + d + "\n")
 	}
 }
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			fetchVisibleGit loads git statuses of the directories on screen.
+//
+//		@Return			tea.Cmd	Background git status command (nil if all cached)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) fetchVisibleGit() tea.Cmd {
+	start, end := m.view.bounds(len(m.rows), m.listHeight())
+	var dirs []string
+	for _, r := range m.rows[start:end] {
+		if !r.header() {
+			dirs = append(dirs, r.dir)
+		}
+	}
+	return fetchGitStatuses(dirs)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			renderStatus renders the last error.
+//
+//		@Return			string	Status line (empty if there is nothing to show)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) renderStatus() string {
+	if m.status == "" {
+		return ""
+	}
+	return "\n  " + lipgloss.NewStyle().Foreground(styles.CurrentTheme.SecondaryColor).Render(m.status)
+}
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	 @Brief			renderCount renders the directory count footer.
@@ -362,5 +1019,9 @@ func (m *CreateMode) renderDirectoryRow(b *strings.Builder, i int, d, q string)
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *CreateMode) renderCount() string {
-	return fmt.Sprintf("\n  %d director(ies)", len(m.filtered))
+	count := fmt.Sprintf("\n  %d director(ies)", len(m.filtered))
+	if createLayout.root != "" {
+		count += " in " + rootName(createLayout.root)
+	}
+	return count
 }
@@ -0,0 +1,234 @@
+package modes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jkeresman01/tsm/git"
+	"github.com/jkeresman01/tsm/session"
+	"github.com/jkeresman01/tsm/styles"
+	"github.com/jkeresman01/tsm/tmux"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			worktreeView is the worktree sub-list of a repository in CreateMode.
+//
+//		@Description	Lists worktrees and optionally prompts for a branch for a new one
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type worktreeView struct {
+	main      git.Worktree    // Main worktree or bare entry, names the repository
+	worktrees []git.Worktree  // Checked out worktrees, main worktree first unless bare
+	cursor    int             // Selected worktree index
+	adding    bool            // Whether the branch prompt is active
+	branch    textinput.Model // Branch name for a new worktree
+	err       string          // Last git error
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			openWorktrees shows the worktrees of the selected repository.
+//
+//		@Description	Shows the git error in the status line if the directory is not a repository.
+//		@Description	The entry of a bare repository names it but is not listed
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) openWorktrees() {
+	if !m.hasSelection() {
+		return
+	}
+	worktrees, err := git.ListWorktrees(m.selectedDir())
+	if err != nil {
+		m.status = "git worktree list failed: " + err.Error()
+		return
+	}
+	if len(worktrees) == 0 {
+		return
+	}
+	m.status = ""
+	m.wt = &worktreeView{
+		main:   worktrees[0],
+		branch: newBranchInput(),
+	}
+	for _, w := range worktrees {
+		if !w.Bare {
+			m.wt.worktrees = append(m.wt.worktrees, w)
+		}
+	}
+	m.input.Blur()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			closeWorktrees returns to the directory list.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) closeWorktrees() {
+	m.wt = nil
+	m.input.Focus()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			updateWorktrees processes messages while the worktree list is open.
+//
+//		@Param			msg		tea.Msg		Input message
+//
+//		@Return			ModeStrategy	Next mode
+//		@Return			tea.Cmd			Optional command
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) updateWorktrees(msg tea.Msg) (ModeStrategy, tea.Cmd) {
+	k, ok := msg.(tea.KeyMsg)
+	if m.wt.adding {
+		if ok && k.String() == "enter" {
+			return m.createWorktree(), nil
+		}
+		if ok && k.String() == "esc" {
+			m.wt.adding = false
+			m.wt.branch.Blur()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.wt.branch, cmd = m.wt.branch.Update(msg)
+		return m, cmd
+	}
+	if !ok {
+		return m, nil
+	}
+	switch k.String() {
+	case "up", "k":
+		m.moveWorktreeCursor(-1)
+	case "down", "j":
+		m.moveWorktreeCursor(1)
+	case "enter":
+		if len(m.wt.worktrees) == 0 {
+			return m, nil
+		}
+		return m.openWorktreeSession(m.wt.worktrees[m.wt.cursor]), nil
+	case "a":
+		m.wt.adding = true
+		m.wt.err = ""
+		m.wt.branch.Reset()
+		return m, m.wt.branch.Focus()
+	case "esc":
+		m.closeWorktrees()
+	}
+	return m, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			moveWorktreeCursor moves the worktree cursor by delta positions.
+//
+//		@Param			delta	int	Number of positions to move (negative for up)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) moveWorktreeCursor(delta int) {
+	m.wt.cursor += delta
+	if m.wt.cursor >= len(m.wt.worktrees) {
+		m.wt.cursor = len(m.wt.worktrees) - 1
+	}
+	if m.wt.cursor < 0 {
+		m.wt.cursor = 0
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			openWorktreeSession creates a "repo@branch" session for a worktree.
+//
+//		@Description	Stays in the worktree list and shows the error if tmux fails,
+//		@Description	e.g. when the session already exists
+//
+//		@Param			w	git.Worktree	Worktree to open
+//
+//		@Return			ModeStrategy	SwitchMode with updated session list, this mode on error
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) openWorktreeSession(w git.Worktree) ModeStrategy {
+	name := tmux.SanitizeSessionName(m.wt.main.RepoName() + "@" + w.Label())
+	if err := session.Create(name, w.Path); err != nil {
+		m.wt.err = fmt.Sprintf("creating session %s failed: %v", name, err)
+		return m
+	}
+	sessions, _ := tmux.ListSessions()
+	return NewSwitchMode(sessions)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			createWorktree adds a worktree for the entered branch and opens it.
+//
+//		@Description	Stays in the prompt and shows the git error on failure
+//
+//		@Return			ModeStrategy	SwitchMode on success, this mode otherwise
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) createWorktree() ModeStrategy {
+	branch := strings.TrimSpace(m.wt.branch.Value())
+	if branch == "" {
+		return m
+	}
+	mainPath := m.wt.main.Path
+	path := git.WorktreePath(mainPath, branch)
+	if err := git.AddWorktree(mainPath, path, branch); err != nil {
+		m.wt.err = "git worktree add failed: " + err.Error()
+		return m
+	}
+	m.wt.adding = false
+	m.wt.branch.Blur()
+	m.wt.worktrees = append(m.wt.worktrees, git.Worktree{Path: path, Branch: branch})
+	m.wt.cursor = len(m.wt.worktrees) - 1
+	return m.openWorktreeSession(m.wt.worktrees[m.wt.cursor])
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			renderWorktrees renders the worktree sub-list.
+//
+//		@Return			string	Rendered worktree list with optional branch prompt
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *CreateMode) renderWorktrees() string {
+	var b strings.Builder
+	b.WriteString("Worktrees of " + m.wt.main.RepoName() + ":\n\n")
+	dim := lipgloss.NewStyle().Foreground(styles.CurrentTheme.SecondaryColor)
+	for i, w := range m.wt.worktrees {
+		prefix := "  "
+		if i == m.wt.cursor {
+			prefix = styles.CurrentIcons.Pointer + " "
+		}
+		b.WriteString(prefix + styles.CurrentIcons.Branch + " " + w.Label())
+		b.WriteString(dim.Render("  " + w.Path))
+		b.WriteByte('\n')
+	}
+	if m.wt.adding {
+		b.WriteString("\nNew worktree branch: " + m.wt.branch.View() + "\n")
+	}
+	if m.wt.err != "" {
+		b.WriteString("\n" + dim.Render(m.wt.err) + "\n")
+	}
+	return b.String()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			newBranchInput creates the input for a new worktree branch.
+//
+//		@Return			textinput.Model	Configured input field
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func newBranchInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "branch name..."
+	ti.Prompt = ""
+	ti.CharLimit = 100
+	ti.Width = 30
+	return ti
+}
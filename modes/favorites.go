@@ -0,0 +1,103 @@
+package modes
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jkeresman01/tsm/styles"
+)
+
+// favoritesPrefix restricts a search to favorites when it starts the query.
+const favoritesPrefix = "*"
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			favoritesQuery strips the favorites-only prefix from a query.
+//
+//		@Param			query	string	Raw search input
+//
+//		@Return			string	Query without the prefix
+//		@Return			bool	True if only favorites should be listed
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func favoritesQuery(query string) (string, bool) {
+	rest, ok := strings.CutPrefix(query, favoritesPrefix)
+	if !ok {
+		return query, false
+	}
+	return strings.TrimSpace(rest), true
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			favoritesFirst moves favorites to the top, keeping the order otherwise.
+//
+//		@Param			items		[]string			Items to order
+//		@Param			isFavorite	func(string) bool	Reports whether an item is a favorite
+//		@Param			only		bool				Drop items that are not favorites
+//
+//		@Return			[]string	New slice with the favorites section first
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func favoritesFirst(items []string, isFavorite func(string) bool, only bool) []string {
+	favs := make([]string, 0, len(items))
+	var rest []string
+	for _, it := range items {
+		if isFavorite(it) {
+			favs = append(favs, it)
+		} else if !only {
+			rest = append(rest, it)
+		}
+	}
+	return append(favs, rest...)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			favoritesSplit finds where the favorites section of a list ends.
+//
+//		@Param			n			int				Number of rows
+//		@Param			isFavorite	func(int) bool	Reports whether a row belongs to the favorites
+//
+//		@Return			int		Index of the first row after the favorites, -1 if the list is not
+//		@Return					split into a favorites section and a rest
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func favoritesSplit(n int, isFavorite func(int) bool) int {
+	i := 0
+	for i < n && isFavorite(i) {
+		i++
+	}
+	if i == 0 || i == n {
+		return -1
+	}
+	return i
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			renderFavoritesSeparator renders the line between favorites and other rows.
+//
+//		@Return			string	Dimmed rule with its newline
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func renderFavoritesSeparator() string {
+	rule := styles.CurrentIcons.Text("  " + strings.Repeat("─", 24))
+	return lipgloss.NewStyle().Foreground(styles.CurrentTheme.SecondaryColor).Render(rule) + "\n"
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			favoritePrefix renders the favorite marker of a row.
+//
+//		@Param			favorite	bool	Whether the row is a favorite
+//
+//		@Return			string	Star icon, or nothing for other rows
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func favoritePrefix(favorite bool) string {
+	if !favorite {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(styles.CurrentTheme.AccentColor).Render(styles.CurrentIcons.Favorite) + " "
+}
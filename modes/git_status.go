@@ -0,0 +1,85 @@
+package modes
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jkeresman01/tsm/git"
+	"github.com/jkeresman01/tsm/styles"
+)
+
+// gitCache holds git statuses shared by every mode for the lifetime of the process.
+var gitCache = git.NewCache()
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			gitStatusMsg reports that the git status of a directory is available.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type gitStatusMsg struct {
+	dir string
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			fetchGitStatuses computes missing git statuses in the background.
+//
+//		@Description	One command per uncached directory, run concurrently by Bubble Tea
+//
+//		@Param			dirs	[]string	Directories currently visible
+//
+//		@Return			tea.Cmd	Batched commands, nil if everything is cached
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func fetchGitStatuses(dirs []string) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, dir := range dirs {
+		if dir == "" || !gitCache.Claim(dir) {
+			continue
+		}
+		d := dir
+		cmds = append(cmds, func() tea.Msg {
+			st, _ := git.GetStatus(d)
+			gitCache.Store(d, st)
+			return gitStatusMsg{dir: d}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			renderGitStatus renders the git decoration for a directory.
+//
+//		@Description	Example: " main ± ↑1↓2", empty until computed or for non-repositories
+//
+//		@Param			dir		string	Directory path
+//
+//		@Return			string	Styled decoration with a leading space
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func renderGitStatus(dir string) string {
+	st, ok := gitCache.Get(dir)
+	if !ok || !st.IsRepo {
+		return ""
+	}
+	icons := styles.CurrentIcons
+	var b strings.Builder
+	b.WriteString(" " + icons.Branch + " " + st.Branch)
+	if st.Dirty {
+		b.WriteString(" " + icons.Dirty)
+	}
+	if st.Ahead > 0 || st.Behind > 0 {
+		b.WriteString(" ")
+	}
+	if st.Ahead > 0 {
+		b.WriteString(fmt.Sprintf("%s%d", icons.Ahead, st.Ahead))
+	}
+	if st.Behind > 0 {
+		b.WriteString(fmt.Sprintf("%s%d", icons.Behind, st.Behind))
+	}
+	return lipgloss.NewStyle().Foreground(styles.CurrentTheme.SecondaryColor).Render(b.String())
+}
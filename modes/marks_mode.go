@@ -0,0 +1,488 @@
+package modes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jkeresman01/tsm/session"
+	"github.com/jkeresman01/tsm/state"
+	"github.com/jkeresman01/tsm/styles"
+	"github.com/jkeresman01/tsm/tmux"
+	"github.com/jkeresman01/tsm/utils"
+	"github.com/jkeresman01/tsm/view/model"
+)
+
+// marksListTop is the number of lines above the first slot row.
+const marksListTop = 2
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			MarksMode shows the numbered mark slots for reordering and editing.
+//
+//		@Description	Each slot pins a session and its directory, see session.OpenMark
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type MarksMode struct {
+	marks   []state.Mark
+	cursor  int
+	clicks  clickTracker
+	input   textinput.Model
+	editing string // Field being edited: "name", "path" or empty
+	status  string
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			NewMarksMode creates a MarksMode with the stored slots.
+//
+//		@Return			*MarksMode	Initialized MarksMode
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func NewMarksMode() *MarksMode {
+	marks, err := state.LoadMarks()
+	m := &MarksMode{marks: marks, input: newMarkInput()}
+	if err != nil {
+		m.status = err.Error()
+	}
+	return m
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Init returns no command, marks are loaded on construction.
+//
+//		@Return			tea.Cmd	Always nil
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) Init() tea.Cmd { return nil }
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Update processes input messages and updates the mode state.
+//
+//		@Param			msg		tea.Msg			Input message
+//
+//		@Return			ModeStrategy	Updated mode state
+//		@Return			tea.Cmd			Optional command
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) Update(msg tea.Msg) (ModeStrategy, tea.Cmd) {
+	switch t := msg.(type) {
+	case tea.KeyMsg:
+		if m.editing != "" {
+			return m.handleEditKey(t)
+		}
+		return m.handleKey(t)
+	case tea.MouseMsg:
+		if m.editing == "" {
+			return m.handleMouse(t)
+		}
+	}
+	return m, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			View renders the mark slots.
+//
+//		@Return			string	Rendered view
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) View() string {
+	var b strings.Builder
+	b.WriteString("Marked sessions:\n\n")
+	for i, mark := range m.marks {
+		b.WriteString(m.renderRow(i, mark))
+		b.WriteByte('\n')
+	}
+	b.WriteString(m.renderStatus())
+	return b.String()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ModeName returns the display name of this mode.
+//
+//		@Return			string	"MARKS MODE"
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) ModeName() string { return "MARKS MODE" }
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			Reset cancels any edit in progress.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) Reset() {
+	m.cancelEdit()
+	m.status = ""
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			CapturesInput reports whether a slot is being edited.
+//
+//		@Return			bool	True while the edit prompt is open
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) CapturesInput() bool { return m.editing != "" }
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			GetCurrentSession returns the session of the selected slot.
+//
+//		@Return			string	Session name or empty string for an empty slot
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) GetCurrentSession() string {
+	mark := m.marks[m.cursor]
+	if mark.Empty() {
+		return ""
+	}
+	return session.MarkSessionName(mark)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			GetIcon returns the mode's icon.
+//
+//		@Return			string	Icon from the active icon set
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) GetIcon() string {
+	return styles.CurrentIcons.Marks
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			GetFooterText returns the help text for the footer.
+//
+//		@Return			string	Keybinding help text
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) GetFooterText() string {
+	if m.editing != "" {
+		return "type value • ↵ save • ⎋ cancel"
+	}
+	return "↑↓ navigate • ↵ open • K/J reorder • r name • e dir • d clear • ? help • q quit"
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			GetShortcuts returns the shortcuts for the help dialog.
+//
+//		@Return			[]model.Shortcut	Marks mode shortcuts
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) GetShortcuts() []model.Shortcut {
+	if m.editing != "" {
+		return []model.Shortcut{
+			{Key: "type", Desc: "Edit value"},
+			{Key: "Enter", Desc: "Save mark"},
+			{Key: "Esc", Desc: "Cancel edit"},
+		}
+	}
+	return []model.Shortcut{
+		{Key: "↑ / k", Desc: "Move up"},
+		{Key: "↓ / j", Desc: "Move down"},
+		{Key: "Enter", Desc: "Open marked session"},
+		{Key: "1-9", Desc: "Open mark in slot"},
+		{Key: "K / Shift+↑", Desc: "Move mark up"},
+		{Key: "J / Shift+↓", Desc: "Move mark down"},
+		{Key: "r", Desc: "Edit session name"},
+		{Key: "e", Desc: "Edit directory"},
+		{Key: "d / Delete", Desc: "Clear slot"},
+		{Key: "Click / Wheel", Desc: "Select slot"},
+		{Key: "Double-click", Desc: "Open clicked mark"},
+		{Key: "Esc", Desc: "Back to switch mode"},
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			handleKey processes keys while browsing slots.
+//
+//		@Param			k		tea.KeyMsg		Keyboard message
+//
+//		@Return			ModeStrategy	Next mode (if changed)
+//		@Return			tea.Cmd			Command to execute
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) handleKey(k tea.KeyMsg) (ModeStrategy, tea.Cmd) {
+	if slot, ok := slotKey(k.String(), ""); ok {
+		return m.open(slot)
+	}
+	switch k.String() {
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "shift+up", "K":
+		m.moveMark(-1)
+	case "shift+down", "J":
+		m.moveMark(1)
+	case "enter":
+		return m.open(m.cursor + 1)
+	case "r":
+		m.startEdit("name")
+	case "e":
+		m.startEdit("path")
+	case "d", "delete":
+		m.marks[m.cursor] = state.Mark{}
+		m.save()
+	case "esc":
+		sessions, _ := tmux.ListSessions()
+		return NewSwitchMode(sessions), nil
+	}
+	return m, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			handleEditKey processes keys while a slot field is being edited.
+//
+//		@Param			k		tea.KeyMsg		Keyboard message
+//
+//		@Return			ModeStrategy	This mode
+//		@Return			tea.Cmd			Command from the input field
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) handleEditKey(k tea.KeyMsg) (ModeStrategy, tea.Cmd) {
+	switch k.String() {
+	case "enter":
+		m.finishEdit()
+		return m, nil
+	case "esc":
+		m.cancelEdit()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(k)
+	return m, cmd
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			handleMouse processes mouse input on the slot list.
+//
+//		@Description	Wheel moves the cursor, click selects, double-click opens
+//
+//		@Param			msg		tea.MouseMsg	Mouse event relative to the mode's view
+//
+//		@Return			ModeStrategy	Next mode (if changed)
+//		@Return			tea.Cmd			Command to execute
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) handleMouse(msg tea.MouseMsg) (ModeStrategy, tea.Cmd) {
+	if delta := wheelDelta(msg); delta != 0 {
+		m.moveCursor(delta)
+		return m, nil
+	}
+	if !isLeftClick(msg) {
+		return m, nil
+	}
+	row := msg.Y - marksListTop
+	if row < 0 || row >= len(m.marks) {
+		return m, nil
+	}
+	m.cursor = row
+	if m.clicks.click(row) {
+		return m.open(row + 1)
+	}
+	return m, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			open attaches to the mark in a slot and quits.
+//
+//		@Param			slot	int		Slot number from 1 to state.MaxMarks
+//
+//		@Return			ModeStrategy	This mode
+//		@Return			tea.Cmd			Quit command on success
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) open(slot int) (ModeStrategy, tea.Cmd) {
+	if _, err := session.OpenMark(slot); !attached(err) {
+		m.status = err.Error()
+		return m, nil
+	}
+	return m, tea.Quit
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			moveCursor moves the cursor by delta slots.
+//
+//		@Param			delta	int	Number of slots to move (negative for up)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) moveCursor(delta int) {
+	m.cursor += delta
+	if m.cursor < 0 {
+		m.cursor = 0
+	} else if m.cursor >= len(m.marks) {
+		m.cursor = len(m.marks) - 1
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			moveMark swaps the selected mark with its neighbour.
+//
+//		@Param			delta	int	-1 to move up, 1 to move down
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) moveMark(delta int) {
+	to := m.cursor + delta
+	if to < 0 || to >= len(m.marks) {
+		return
+	}
+	m.marks[m.cursor], m.marks[to] = m.marks[to], m.marks[m.cursor]
+	m.cursor = to
+	m.save()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			startEdit opens the prompt for a field of the selected mark.
+//
+//		@Param			field	string	"name" or "path"
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) startEdit(field string) {
+	mark := m.marks[m.cursor]
+	value := mark.Path
+	if field == "name" {
+		value = mark.Name
+	}
+	m.editing = field
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	m.input.Focus()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			finishEdit stores the edited field and saves the marks.
+//
+//		@Description	A directory starting with ~ is expanded, tmux does not expand it
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) finishEdit() {
+	value := strings.TrimSpace(m.input.Value())
+	if m.editing == "name" {
+		m.marks[m.cursor].Name = value
+	} else {
+		m.marks[m.cursor].Path = utils.ExpandHome(value)
+	}
+	m.cancelEdit()
+	m.save()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			cancelEdit closes the edit prompt without saving.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) cancelEdit() {
+	m.editing = ""
+	m.input.Reset()
+	m.input.Blur()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			save persists the slots and reports failures in the status line.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) save() {
+	m.status = ""
+	if err := state.SaveMarks(m.marks); err != nil {
+		m.status = err.Error()
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			renderRow renders a single slot.
+//
+//		@Param			i		int			Slot index
+//		@Param			mark	state.Mark	Mark in the slot
+//
+//		@Return			string	Slot number, session name and directory
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) renderRow(i int, mark state.Mark) string {
+	prefix := "  "
+	if i == m.cursor {
+		prefix = styles.CurrentIcons.Pointer + " "
+	}
+	dim := lipgloss.NewStyle().Foreground(styles.CurrentTheme.SecondaryColor)
+	slot := fmt.Sprintf("%d  ", i+1)
+	if mark.Empty() {
+		return prefix + slot + dim.Render(styles.CurrentIcons.Text("—"))
+	}
+	return prefix + slot + session.MarkSessionName(mark) + "  " + dim.Render(mark.Path)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			renderStatus renders the edit prompt or the last error.
+//
+//		@Return			string	Status lines (empty if there is nothing to show)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *MarksMode) renderStatus() string {
+	switch {
+	case m.editing == "name":
+		return "\nSession name: " + m.input.View()
+	case m.editing == "path":
+		return "\nDirectory: " + m.input.View()
+	case m.status != "":
+		return "\n" + lipgloss.NewStyle().Foreground(styles.CurrentTheme.SecondaryColor).Render(m.status)
+	}
+	return ""
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			slotKey parses a mark slot key such as "3" or "alt+3".
+//
+//		@Param			key		string	Key string from tea.KeyMsg
+//		@Param			prefix	string	Required modifier prefix, e.g. "alt+"
+//
+//		@Return			int		Slot number
+//		@Return			bool	True if the key selects a slot
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func slotKey(key, prefix string) (int, bool) {
+	digit, ok := strings.CutPrefix(key, prefix)
+	if !ok || len(digit) != 1 {
+		return 0, false
+	}
+	slot, err := strconv.Atoi(digit)
+	if err != nil || slot < 1 || slot > state.MaxMarks {
+		return 0, false
+	}
+	return slot, true
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			newMarkInput creates the input used to edit marks.
+//
+//		@Return			textinput.Model	Configured input field
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func newMarkInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.CharLimit = 256
+	ti.Width = 40
+	return ti
+}
@@ -0,0 +1,75 @@
+package modes
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// doubleClickInterval is the maximum delay between two clicks forming a double-click.
+const doubleClickInterval = 400 * time.Millisecond
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			clickTracker detects double-clicks on list rows.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type clickTracker struct {
+	row int       // Row of the previous click
+	at  time.Time // Time of the previous click
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			click registers a click on a row.
+//
+//		@Param			row		int		Clicked row index
+//
+//		@Return			bool	True if this click completes a double-click on the same row
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (c *clickTracker) click(row int) bool {
+	now := time.Now()
+	double := row == c.row && now.Sub(c.at) <= doubleClickInterval
+	if double {
+		c.at = time.Time{}
+	} else {
+		c.row, c.at = row, now
+	}
+	return double
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			isLeftClick reports whether a mouse event is a left button press.
+//
+//		@Param			msg		tea.MouseMsg	Mouse event
+//
+//		@Return			bool	True for a left button press
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func isLeftClick(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			wheelDelta converts a wheel event into a cursor movement.
+//
+//		@Param			msg		tea.MouseMsg	Mouse event
+//
+//		@Return			int		-1 for wheel up, 1 for wheel down, 0 otherwise
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func wheelDelta(msg tea.MouseMsg) int {
+	if msg.Action != tea.MouseActionPress {
+		return 0
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return -1
+	case tea.MouseButtonWheelDown:
+		return 1
+	}
+	return 0
+}
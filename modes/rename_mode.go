@@ -1,14 +1,20 @@
 package modes
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jkeresman01/tsm/styles"
 	"github.com/jkeresman01/tsm/tmux"
 	"github.com/jkeresman01/tsm/utils"
+	"github.com/jkeresman01/tsm/view/model"
 )
 
+// renameListTop is the number of lines above the first session row.
+const renameListTop = 2
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	 @Brief			RenameMode handles renaming existing tmux sessions.
@@ -24,6 +30,8 @@ type RenameMode struct {
 	renameInput     textinput.Model
 	renaming        bool
 	selectedSession string
+	view            viewport
+	clicks          clickTracker
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -57,6 +65,15 @@ func NewRenameMode(session string) *RenameMode {
 	}
 }
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Init returns no command, rename mode loads nothing in the background.
+//
+//		@Return			tea.Cmd	Always nil
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *RenameMode) Init() tea.Cmd { return nil }
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	 @Brief			Update processes input messages and updates the mode state.
@@ -68,10 +85,19 @@ func NewRenameMode(session string) *RenameMode {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *RenameMode) Update(msg tea.Msg) (ModeStrategy, tea.Cmd) {
-	if key, ok := msg.(tea.KeyMsg); ok {
-		if next, cmd, done := m.handleKey(key); done {
+	switch t := msg.(type) {
+	case tea.KeyMsg:
+		if next, cmd, done := m.handleKey(t); done {
 			return next, cmd
 		}
+	case tea.MouseMsg:
+		if !m.renaming {
+			m.handleMouse(t)
+		}
+		return m, nil
+	case SessionsChangedMsg:
+		m.refresh(t.Sessions)
+		return m, nil
 	}
 
 	if m.renaming {
@@ -208,15 +234,44 @@ func (m *RenameMode) handleSelectionKeys(k tea.KeyMsg) (ModeStrategy, tea.Cmd, b
 	return nil, nil, false
 }
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			handleMouse processes mouse input during session selection.
+//
+//		@Description	Wheel moves the cursor, click selects, double-click starts renaming
+//
+//		@Param			msg		tea.MouseMsg	Mouse event relative to the mode's view
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *RenameMode) handleMouse(msg tea.MouseMsg) {
+	if delta := wheelDelta(msg); delta != 0 {
+		m.moveCursor(delta)
+		return
+	}
+	if !isLeftClick(msg) {
+		return
+	}
+	line := msg.Y - renameListTop
+	row := m.view.offset + line
+	if line < 0 || row >= len(m.filtered) {
+		return
+	}
+	m.cursor = row
+	m.clampCursor()
+	if m.clicks.click(row) {
+		m.startRename()
+	}
+}
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	 @Brief			GetIcon returns the mode's icon.
 //
-//		@Return			string	Nerd font icon
+//		@Return			string	Icon from the active icon set
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *RenameMode) GetIcon() string {
-	return "󰑕"
+	return styles.CurrentIcons.Rename
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -233,6 +288,34 @@ func (m *RenameMode) GetFooterText() string {
 	return "↑↓ navigate • ↵ select/confirm • ⎋ cancel • ⇥ cycle • ? help • q quit"
 }
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			GetShortcuts returns the shortcuts for the help dialog.
+//
+//		@Description	Differs between session selection and name input
+//
+//		@Return			[]model.Shortcut	Rename mode shortcuts
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *RenameMode) GetShortcuts() []model.Shortcut {
+	if m.renaming {
+		return []model.Shortcut{
+			{Key: "type", Desc: "Edit new session name"},
+			{Key: "Enter", Desc: "Confirm rename"},
+			{Key: "Esc", Desc: "Cancel and return to selection"},
+		}
+	}
+	return []model.Shortcut{
+		{Key: "↑ / k", Desc: "Move up"},
+		{Key: "↓ / j", Desc: "Move down"},
+		{Key: "Enter", Desc: "Rename selected session"},
+		{Key: "Click / Wheel", Desc: "Select session"},
+		{Key: "Double-click", Desc: "Rename clicked session"},
+		{Key: "Esc", Desc: "Back to switch mode"},
+		{Key: "type", Desc: "Search sessions"},
+	}
+}
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	 @Brief			updateRenameInput updates the rename input field.
@@ -305,6 +388,18 @@ func (m *RenameMode) clampCursor() {
 	} else if m.cursor >= n {
 		m.cursor = n - 1
 	}
+	m.view.follow(m.cursor, n, m.listHeight())
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			listHeight returns the number of session rows that fit on screen.
+//
+//		@Return			int		Visible row count
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *RenameMode) listHeight() int {
+	return bodyHeight(renameListTop)
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -323,6 +418,29 @@ func (m *RenameMode) confirmRename() ModeStrategy {
 	return NewSwitchMode(sessions)
 }
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			refresh replaces the session list after a tmux notification.
+//
+//		@Description	Keeps the selected session under the cursor and cancels a rename
+//		@Description	whose session no longer exists
+//
+//		@Param			sessions	[]string	Current session names
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *RenameMode) refresh(sessions []string) {
+	selected := m.GetCurrentSession()
+	m.sessions = sessions
+	m.applyFilter()
+	if i := slices.Index(m.filtered, selected); i >= 0 {
+		m.cursor = i
+	}
+	m.clampCursor()
+	if m.renaming && !slices.Contains(sessions, m.selectedSession) {
+		m.cancelRename()
+	}
+}
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	@Brief			cancelRename cancels the rename operation.
@@ -410,9 +528,10 @@ func (m *RenameMode) renderSessionList() string {
 	var b strings.Builder
 	b.WriteString("Select session to rename:\n\n")
 	q := m.query()
-	for i, s := range m.filtered {
+	start, end := m.view.bounds(len(m.filtered), m.listHeight())
+	for i := start; i < end; i++ {
 		b.WriteString(m.rowPrefix(i))
-		b.WriteString(utils.HighlightMatches(s, q))
+		b.WriteString(utils.HighlightMatches(m.filtered[i], q))
 		b.WriteByte('\n')
 	}
 	return b.String()
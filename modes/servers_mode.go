@@ -0,0 +1,332 @@
+package modes
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jkeresman01/tsm/session"
+	"github.com/jkeresman01/tsm/styles"
+	"github.com/jkeresman01/tsm/tmux"
+	"github.com/jkeresman01/tsm/view/model"
+)
+
+// serversListTop is the number of lines above the first row.
+const serversListTop = 2
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			serverRow is a line of the servers list.
+//
+//		@Description	A row without a session is the header of its server
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type serverRow struct {
+	server  tmux.Server
+	session string
+	count   int   // Number of sessions, headers only
+	err     error // Error listing the server's sessions, headers only
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ServersMode lists sessions across every discovered tmux server.
+//
+//		@Description	Servers are found through their sockets in tmux.SocketDir
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type ServersMode struct {
+	rows   []serverRow
+	cursor int
+	clicks clickTracker
+	status string
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			NewServersMode creates a ServersMode with the discovered servers.
+//
+//		@Return			*ServersMode	Initialized ServersMode
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func NewServersMode() *ServersMode {
+	m := &ServersMode{}
+	m.reload()
+	return m
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			reload queries every discovered server for its sessions.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) reload() {
+	m.rows = nil
+	for _, server := range tmux.DiscoverServers() {
+		sessions, err := tmux.ListServerSessions(server)
+		m.rows = append(m.rows, serverRow{server: server, count: len(sessions), err: err})
+		for _, s := range sessions {
+			m.rows = append(m.rows, serverRow{server: server, session: s})
+		}
+	}
+	m.moveCursor(0)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Init returns no command, servers are loaded on construction.
+//
+//		@Return			tea.Cmd	Always nil
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) Init() tea.Cmd { return nil }
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Update processes input messages and updates the mode state.
+//
+//		@Param			msg		tea.Msg			Input message
+//
+//		@Return			ModeStrategy	Updated mode state
+//		@Return			tea.Cmd			Optional command
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) Update(msg tea.Msg) (ModeStrategy, tea.Cmd) {
+	switch t := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(t)
+	case tea.MouseMsg:
+		return m.handleMouse(t)
+	case SessionsChangedMsg:
+		m.reload()
+	}
+	return m, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			View renders the servers and their sessions.
+//
+//		@Return			string	Rendered view
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) View() string {
+	var b strings.Builder
+	b.WriteString("Tmux servers:\n\n")
+	for i, row := range m.rows {
+		b.WriteString(m.renderRow(i, row))
+		b.WriteByte('\n')
+	}
+	if m.status != "" {
+		b.WriteString("\n" + lipgloss.NewStyle().Foreground(styles.CurrentTheme.SecondaryColor).Render(m.status))
+	}
+	return b.String()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ModeName returns the display name of this mode.
+//
+//		@Return			string	"SERVERS MODE"
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) ModeName() string { return "SERVERS MODE" }
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			Reset clears the status line.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) Reset() {
+	m.status = ""
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			GetCurrentSession returns the selected session.
+//
+//		@Return			string	Session name or empty string on a server header
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) GetCurrentSession() string {
+	if m.cursor >= len(m.rows) {
+		return ""
+	}
+	return m.rows[m.cursor].session
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			GetIcon returns the mode's icon.
+//
+//		@Return			string	Icon from the active icon set
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) GetIcon() string {
+	return styles.CurrentIcons.Servers
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			GetFooterText returns the help text for the footer.
+//
+//		@Return			string	Keybinding help text
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) GetFooterText() string {
+	return "↑↓ navigate • ↵ attach / use server • ⎋ back • ? help • q quit"
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			GetShortcuts returns the shortcuts for the help dialog.
+//
+//		@Return			[]model.Shortcut	Servers mode shortcuts
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) GetShortcuts() []model.Shortcut {
+	return []model.Shortcut{
+		{Key: "↑ / k", Desc: "Move up"},
+		{Key: "↓ / j", Desc: "Move down"},
+		{Key: "Enter", Desc: "Attach session / use server"},
+		{Key: "Click / Wheel", Desc: "Select row"},
+		{Key: "Double-click", Desc: "Open clicked row"},
+		{Key: "Esc", Desc: "Back to switch mode"},
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			handleKey processes keyboard input.
+//
+//		@Param			k		tea.KeyMsg		Keyboard message
+//
+//		@Return			ModeStrategy	Next mode (if changed)
+//		@Return			tea.Cmd			Command to execute
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) handleKey(k tea.KeyMsg) (ModeStrategy, tea.Cmd) {
+	switch k.String() {
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "enter":
+		return m.open()
+	case "esc":
+		sessions, _ := tmux.ListSessions()
+		return NewSwitchMode(sessions), nil
+	}
+	return m, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			handleMouse processes mouse input on the list.
+//
+//		@Description	Wheel moves the cursor, click selects, double-click opens
+//
+//		@Param			msg		tea.MouseMsg	Mouse event relative to the mode's view
+//
+//		@Return			ModeStrategy	Next mode (if changed)
+//		@Return			tea.Cmd			Command to execute
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) handleMouse(msg tea.MouseMsg) (ModeStrategy, tea.Cmd) {
+	if delta := wheelDelta(msg); delta != 0 {
+		m.moveCursor(delta)
+		return m, nil
+	}
+	if !isLeftClick(msg) {
+		return m, nil
+	}
+	row := msg.Y - serversListTop
+	if row < 0 || row >= len(m.rows) {
+		return m, nil
+	}
+	m.cursor = row
+	if m.clicks.click(row) {
+		return m.open()
+	}
+	return m, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			open selects the server of the current row.
+//
+//		@Description	On a session row it also attaches to the session and quits,
+//		@Description	on a header it continues in switch mode on that server
+//
+//		@Return			ModeStrategy	Next mode
+//		@Return			tea.Cmd			Quit command after attaching
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) open() (ModeStrategy, tea.Cmd) {
+	if m.cursor >= len(m.rows) {
+		return m, nil
+	}
+	row := m.rows[m.cursor]
+	if row.err != nil {
+		m.status = fmt.Sprintf("server %s is not running", row.server.Label())
+		return m, nil
+	}
+	tmux.SetServer(row.server)
+	if row.session == "" {
+		sessions, _ := tmux.ListSessions()
+		return NewSwitchMode(sessions), nil
+	}
+	if err := session.Attach(row.session); !attached(err) {
+		m.status = err.Error()
+		return m, nil
+	}
+	return m, tea.Quit
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			moveCursor moves the cursor by delta rows.
+//
+//		@Param			delta	int	Number of rows to move (negative for up)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) moveCursor(delta int) {
+	m.cursor += delta
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			renderRow renders a server header or one of its sessions.
+//
+//		@Param			i		int			Row index
+//		@Param			row		serverRow	Row to render
+//
+//		@Return			string	Rendered row
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *ServersMode) renderRow(i int, row serverRow) string {
+	prefix := "  "
+	if i == m.cursor {
+		prefix = "> "
+	}
+	dim := lipgloss.NewStyle().Foreground(styles.CurrentTheme.SecondaryColor)
+	if row.session != "" {
+		return prefix + "    " + row.session
+	}
+	name := lipgloss.NewStyle().Bold(true).Render(row.server.Label())
+	if row.server == tmux.CurrentServer() {
+		name += " " + lipgloss.NewStyle().Foreground(styles.CurrentTheme.HighlightColor).Render("(current)")
+	}
+	detail := fmt.Sprintf("%d session(s)", row.count)
+	if row.err != nil {
+		detail = "not running"
+	}
+	return prefix + name + "  " + dim.Render(styles.CurrentIcons.Text(detail+" • ")+row.server.Socket())
+}
@@ -0,0 +1,89 @@
+package modes
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jkeresman01/tsm/styles"
+	"github.com/jkeresman01/tsm/tmux"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			nestGroups moves grouped sessions right after the first member of their group.
+//
+//		@Description	The sort order is kept otherwise: a group sits where its first member
+//		@Description	sorted, followed by the other members in sort order
+//
+//		@Param			sessions	[]string					Sorted session names
+//		@Param			info		map[string]tmux.SessionInfo	Session details with groups
+//
+//		@Return			[]string	Sessions with group members adjacent
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func nestGroups(sessions []string, info map[string]tmux.SessionInfo) []string {
+	members := make(map[string][]string)
+	for _, s := range sessions {
+		if g := info[s].Group; g != "" {
+			members[g] = append(members[g], s)
+		}
+	}
+	out := make([]string, 0, len(sessions))
+	for _, s := range sessions {
+		g := info[s].Group
+		switch {
+		case g == "":
+			out = append(out, s)
+		case members[g] != nil:
+			out = append(out, members[g]...)
+			members[g] = nil
+		}
+	}
+	return out
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			groupFavorites extends a favorite check to whole session groups.
+//
+//		@Description	Used to order favorites first without pulling a favorite out of its
+//		@Description	group, which would break the nesting built by nestGroups
+//
+//		@Param			sessions	[]string					Sessions to consider
+//		@Param			info		map[string]tmux.SessionInfo	Session details with groups
+//		@Param			isFavorite	func(string) bool			Favorite check for a single session
+//
+//		@Return			func(string) bool	True for favorites and members of their groups
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func groupFavorites(sessions []string, info map[string]tmux.SessionInfo, isFavorite func(string) bool) func(string) bool {
+	groups := make(map[string]bool)
+	for _, s := range sessions {
+		if g := info[s].Group; g != "" && isFavorite(s) {
+			groups[g] = true
+		}
+	}
+	return func(s string) bool {
+		return isFavorite(s) || groups[info[s].Group]
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			groupPrefix renders the nesting marker of a session row.
+//
+//		@Description	A session is nested when the row above belongs to the same group
+//
+//		@Param			rows	[]string					Displayed session names
+//		@Param			i		int							Row index
+//		@Param			info	map[string]tmux.SessionInfo	Session details with groups
+//
+//		@Return			string	Indented tree marker, or nothing for other rows
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func groupPrefix(rows []string, i int, info map[string]tmux.SessionInfo) string {
+	g := info[rows[i]].Group
+	if g == "" || i == 0 || info[rows[i-1]].Group != g {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(styles.CurrentTheme.SecondaryColor).Render(styles.CurrentIcons.Nested) + " "
+}
@@ -0,0 +1,240 @@
+package modes
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jkeresman01/tsm/git"
+	"github.com/jkeresman01/tsm/state"
+	"github.com/jkeresman01/tsm/tmux"
+)
+
+// Sort orders offered by switch mode, the first one is the default.
+var sessionSorts = []string{"name", "activity", "created", "frecency", "windows"}
+
+// Sort orders offered by create mode, the first one is the default.
+var dirSorts = []string{"frecency", "name", "mtime", "commit"}
+
+// commitLoadWorkers bounds the number of concurrent 'git log' calls.
+const commitLoadWorkers = 8
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			commitTimesMsg reports that commit times were added to the cache.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type commitTimesMsg struct{}
+
+var (
+	commitTimesMu sync.Mutex
+	commitTimes   = make(map[string]time.Time) // Last commit time by directory
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			nextSort returns the order following current in options.
+//
+//		@Param			options	[]string	Available orders
+//		@Param			current	string		Active order
+//
+//		@Return			string	Next order, wrapping around
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func nextSort(options []string, current string) string {
+	i := slices.Index(options, current)
+	return options[(i+1)%len(options)]
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			validSort returns current if it is one of options, the default otherwise.
+//
+//		@Param			options	[]string	Available orders
+//		@Param			current	string		Stored order
+//
+//		@Return			string	Usable order
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func validSort(options []string, current string) string {
+	if slices.Contains(options, current) {
+		return current
+	}
+	return options[0]
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			sortSessions orders sessions for switch mode.
+//
+//		@Description	Times and counts sort descending, names ascending; ties keep tmux order
+//
+//		@Param			sessions	[]string					Sessions in tmux order
+//		@Param			by			string						Sort order
+//		@Param			info		map[string]tmux.SessionInfo	Session details
+//		@Param			frecency	map[string]float64			Visit scores from the jump list
+//
+//		@Return			[]string	New, sorted slice
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func sortSessions(sessions []string, by string, info map[string]tmux.SessionInfo, frecency map[string]float64) []string {
+	out := slices.Clone(sessions)
+	slices.SortStableFunc(out, func(a, b string) int {
+		switch by {
+		case "activity":
+			return info[b].Activity.Compare(info[a].Activity)
+		case "created":
+			return info[b].Created.Compare(info[a].Created)
+		case "frecency":
+			return cmp.Compare(frecency[b], frecency[a])
+		case "windows":
+			return cmp.Compare(info[b].Windows, info[a].Windows)
+		default:
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		}
+	})
+	return out
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			sortDirs orders directories for create mode.
+//
+//		@Description	"frecency" ranks directories by the sessions created in them; ties keep
+//		@Description	the ranked order of the scanned and external sources
+//
+//		@Param			dirs		[]string			Directories in ranked order
+//		@Param			by			string				Sort order
+//		@Param			frecency	map[string]float64	Visit scores from the sessions created by tsm
+//
+//		@Return			[]string	New, sorted slice
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func sortDirs(dirs []string, by string, frecency map[string]float64) []string {
+	out := slices.Clone(dirs)
+	switch by {
+	case "frecency":
+		slices.SortStableFunc(out, func(a, b string) int { return cmp.Compare(frecency[b], frecency[a]) })
+	case "name":
+		slices.SortStableFunc(out, func(a, b string) int {
+			return strings.Compare(strings.ToLower(filepath.Base(a)), strings.ToLower(filepath.Base(b)))
+		})
+	case "mtime":
+		mtimes := make(map[string]time.Time, len(out))
+		for _, d := range out {
+			if info, err := os.Stat(d); err == nil {
+				mtimes[d] = info.ModTime()
+			}
+		}
+		slices.SortStableFunc(out, func(a, b string) int { return mtimes[b].Compare(mtimes[a]) })
+	case "commit":
+		commitTimesMu.Lock()
+		defer commitTimesMu.Unlock()
+		slices.SortStableFunc(out, func(a, b string) int { return commitTimes[b].Compare(commitTimes[a]) })
+	}
+	return out
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			missingCommitTimes returns the directories without a cached commit time.
+//
+//		@Param			dirs	[]string	Directories to check
+//
+//		@Return			[]string	Directories still to load
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func missingCommitTimes(dirs []string) []string {
+	commitTimesMu.Lock()
+	defer commitTimesMu.Unlock()
+	var missing []string
+	for _, d := range dirs {
+		if _, ok := commitTimes[d]; !ok {
+			missing = append(missing, d)
+		}
+	}
+	return missing
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			fetchCommitTimes loads the last commit time of directories in the background.
+//
+//		@Description	Results are stored in the cache before the message is delivered
+//
+//		@Param			dirs	[]string	Directories to load
+//
+//		@Return			tea.Cmd	Command delivering a commitTimesMsg (nil if nothing to load)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func fetchCommitTimes(dirs []string) tea.Cmd {
+	if len(dirs) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		times := make(map[string]time.Time, len(dirs))
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, commitLoadWorkers)
+		for _, d := range dirs {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(dir string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				t, _ := git.LastCommit(dir)
+				mu.Lock()
+				times[dir] = t
+				mu.Unlock()
+			}(d)
+		}
+		wg.Wait()
+		storeCommitTimes(times)
+		return commitTimesMsg{}
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			storeCommitTimes adds loaded commit times to the cache.
+//
+//		@Param			times	map[string]time.Time	Loaded commit times
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func storeCommitTimes(times map[string]time.Time) {
+	commitTimesMu.Lock()
+	defer commitTimesMu.Unlock()
+	for d, t := range times {
+		commitTimes[d] = t
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			saveSortPref updates a remembered sort order in the preferences.
+//
+//		@Description	Nothing is written when the preferences cannot be read, so the other
+//		@Description	mode's remembered order is not overwritten with defaults
+//
+//		@Param			set		func(*state.Prefs)	Stores the new order in the preferences
+//
+//		@Return			error	Error if the preferences cannot be read or saved
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func saveSortPref(set func(*state.Prefs)) error {
+	prefs, err := state.LoadPrefs()
+	if err != nil {
+		return fmt.Errorf("sort order not saved, reading preferences: %w", err)
+	}
+	set(&prefs)
+	if err := state.SavePrefs(prefs); err != nil {
+		return fmt.Errorf("sort order not saved: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,349 @@
+package modes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+
+	"github.com/jkeresman01/tsm/state"
+	"github.com/jkeresman01/tsm/tmux"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			toggleMark marks or unmarks the selected session and moves down.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) toggleMark() {
+	if !m.hasSelection() {
+		return
+	}
+	s := m.filtered[m.cursor]
+	if m.marked[s] {
+		delete(m.marked, s)
+	} else {
+		m.marked[s] = true
+	}
+	m.moveCursor(1)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			toggleMarkAll marks every filtered session.
+//
+//		@Description	If all filtered sessions are already marked they are unmarked instead
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) toggleMarkAll() {
+	all := true
+	for _, s := range m.filtered {
+		if !m.marked[s] {
+			all = false
+			break
+		}
+	}
+	for _, s := range m.filtered {
+		if all {
+			delete(m.marked, s)
+		} else {
+			m.marked[s] = true
+		}
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			batchTargets returns the sessions a batch action applies to.
+//
+//		@Return			[]string	Marked sessions in list order, or the selected session
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) batchTargets() []string {
+	var targets []string
+	for _, s := range m.sessions {
+		if m.marked[s] {
+			targets = append(targets, s)
+		}
+	}
+	if len(targets) == 0 && m.hasSelection() {
+		targets = append(targets, m.filtered[m.cursor])
+	}
+	return targets
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			runBatch applies an operation to every batch target.
+//
+//		@Description	Clears the marks, reloads sessions and stores a result summary
+//
+//		@Param			verb	string					Past-tense action name for the summary
+//		@Param			op		func(string) error		Operation to run per session
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) runBatch(verb string, op func(string) error) {
+	targets := m.batchTargets()
+	if len(targets) == 0 {
+		return
+	}
+	var failed []string
+	for _, s := range targets {
+		if err := op(s); err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%v)", s, err))
+		}
+	}
+	m.status = summarizeBatch(verb, len(targets)-len(failed), failed)
+	m.marked = make(map[string]bool)
+	m.reloadSessions()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			confirmKill kills the targets, asking first when there are several.
+//
+//		@Description	Only "y" or "yes" confirms, anything else cancels
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) confirmKill() {
+	targets := m.batchTargets()
+	if len(targets) <= 1 {
+		m.killTargets()
+		return
+	}
+	m.startPrompt(fmt.Sprintf("Kill %d sessions? [y/N] ", len(targets)), "", func(answer string) {
+		if answer = strings.ToLower(answer); answer == "y" || answer == "yes" {
+			m.killTargets()
+		}
+	})
+	m.prompt.Placeholder = "n"
+	m.hint = "y ↵ kill • ↵ / ⎋ cancel"
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			killTargets kills the marked or selected sessions.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) killTargets() {
+	m.runBatch("killed", tmux.KillSession)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			detachTargets detaches all clients of the marked or selected sessions.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) detachTargets() {
+	m.runBatch("detached", tmux.DetachClients)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			confirmGroup asks before moving the targets into a session group.
+//
+//		@Description	The target must exist and must not be one of the moved sessions.
+//		@Description	Only "y" or "yes" confirms, since the moved sessions are recreated
+//		@Description	and lose their clients
+//
+//		@Param			target	string	Session whose group to join
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) confirmGroup(target string) {
+	targets := m.batchTargets()
+	if slices.Contains(targets, target) {
+		m.status = fmt.Sprintf("cannot group %s into itself", target)
+		return
+	}
+	if !tmux.HasSession(target) {
+		m.status = fmt.Sprintf("session %s not found", target)
+		return
+	}
+	label := fmt.Sprintf("Move %d session(s) into the group of %s? Clients are detached [y/N] ", len(targets), target)
+	m.startPrompt(label, "", func(answer string) {
+		if answer = strings.ToLower(answer); answer == "y" || answer == "yes" {
+			m.groupTargets(targets, target)
+		}
+	})
+	m.prompt.Placeholder = "n"
+	m.hint = "y ↵ group • ↵ / ⎋ cancel"
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			groupTargets moves sessions into a session group.
+//
+//		@Description	Stops at the first failure and lists the sessions left untouched
+//
+//		@Param			targets	[]string	Sessions to move
+//		@Param			target	string		Session whose group to join
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) groupTargets(targets []string, target string) {
+	for i, s := range targets {
+		if err := tmux.JoinGroup(s, target); err != nil {
+			m.status = summarizeBatch("grouped", i, []string{fmt.Sprintf("%s (%v)", s, err)})
+			if rest := targets[i+1:]; len(rest) > 0 {
+				m.status += " • not moved: " + strings.Join(rest, ", ")
+			}
+			break
+		}
+		m.status = summarizeBatch("grouped", i+1, nil)
+	}
+	m.marked = make(map[string]bool)
+	m.reloadSessions()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			snapshotTargets saves the marked or selected sessions to a snapshot file.
+//
+//		@Description	Layouts are read in one tmux call, sessions that cannot be read are
+//		@Description	reported as failures
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) snapshotTargets() {
+	snap := state.Snapshot{Created: time.Now()}
+	layouts := tmux.ListSessionLayouts(m.batchTargets())
+	m.runBatch("snapshotted", func(s string) error {
+		layout, ok := layouts[s]
+		if !ok {
+			return fmt.Errorf("cannot read session")
+		}
+		snap.Sessions = append(snap.Sessions, snapshotSession(s, layout))
+		return nil
+	})
+	if len(snap.Sessions) == 0 {
+		return
+	}
+	path, err := state.SaveSnapshot(snap)
+	if err != nil {
+		m.status = "snapshot not saved: " + err.Error()
+		return
+	}
+	m.status += " → " + path
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			snapshotSession records the path and windows of a session.
+//
+//		@Param			name	string				Session name
+//		@Param			layout	tmux.SessionLayout	Layout read from tmux
+//
+//		@Return			state.SessionSnapshot	Recorded session
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func snapshotSession(name string, layout tmux.SessionLayout) state.SessionSnapshot {
+	snap := state.SessionSnapshot{Name: name, Path: layout.Path}
+	for _, w := range layout.Windows {
+		snap.Windows = append(snap.Windows, state.WindowSnapshot{Name: w.Name, Path: w.Path})
+	}
+	return snap
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			summarizeBatch formats the outcome of a batch action.
+//
+//		@Param			verb	string		Past-tense action name
+//		@Param			ok		int			Number of successful sessions
+//		@Param			failed	[]string	Failed sessions with their errors
+//
+//		@Return			string	Summary line
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func summarizeBatch(verb string, ok int, failed []string) string {
+	summary := fmt.Sprintf("%s %d session(s)", verb, ok)
+	if len(failed) > 0 {
+		summary += fmt.Sprintf(" • %d failed: %s", len(failed), strings.Join(failed, ", "))
+	}
+	return summary
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			startGroupPrompt asks for the session whose group the targets should join.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) startGroupPrompt() {
+	if len(m.batchTargets()) == 0 {
+		return
+	}
+	m.startPrompt("Move into group of: ", "", func(target string) {
+		if target != "" {
+			m.confirmGroup(target)
+		}
+	})
+	m.prompt.Placeholder = "Target session..."
+	m.hint = "type target session • ↵ group • ⎋ cancel"
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			startPrompt opens the prompt below the session list.
+//
+//		@Param			label	string				Text shown before the input
+//		@Param			value	string				Initial input value
+//		@Param			apply	func(value string)	Action run when the prompt is confirmed
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) startPrompt(label, value string, apply func(value string)) {
+	m.prompting = true
+	m.label = label
+	m.apply = apply
+	m.prompt.Reset()
+	m.prompt.SetValue(value)
+	m.prompt.CursorEnd()
+	m.prompt.Focus()
+	m.input.Blur()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			finishPrompt closes the prompt.
+//
+//		@Description	The action may open a follow-up prompt, e.g. a confirmation
+//
+//		@Param			confirm	bool	Whether to run the prompt's action with the entered value
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) finishPrompt(confirm bool) {
+	value := strings.TrimSpace(m.prompt.Value())
+	m.prompting = false
+	m.prompt.Blur()
+	m.input.Focus()
+	apply := m.apply
+	m.apply = nil
+	if confirm && apply != nil {
+		apply(value)
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			reloadSessions refreshes the session list after a batch action.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) reloadSessions() {
+	sessions, _ := tmux.ListSessions()
+	m.setSessions(sessions)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			newGroupPrompt creates the input used by the group and tag prompts.
+//
+//		@Return			textinput.Model	Configured input field
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func newGroupPrompt() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "Target session..."
+	ti.Prompt = ""
+	ti.CharLimit = 64
+	ti.Width = 20
+	return ti
+}
@@ -1,13 +1,21 @@
 package modes
 
 import (
+	"errors"
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
+	"github.com/jkeresman01/tsm/session"
+	"github.com/jkeresman01/tsm/state"
+	"github.com/jkeresman01/tsm/styles"
 	"github.com/jkeresman01/tsm/tmux"
 	"github.com/jkeresman01/tsm/utils"
+	"github.com/jkeresman01/tsm/view/model"
 )
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -22,8 +30,38 @@ type SwitchMode struct {
 	filtered []string        // Filtered sessions based on search query
 	cursor   int             // Currently selected index
 	input    textinput.Model // Search input field
+	view     viewport        // Visible slice of the filtered list
+	clicks   clickTracker    // Double-click detection
+
+	marked    map[string]bool    // Sessions selected for batch actions
+	status    string             // Summary of the last batch action
+	prompt    textinput.Model    // Group target or tag input
+	prompting bool               // Whether the prompt is active
+	label     string             // Text shown before the prompt
+	hint      string             // Footer text while the prompt is active
+	apply     func(value string) // Action run with the confirmed prompt value
+
+	info     map[string]tmux.SessionInfo // Session details for sorting and git decorations
+	favs     state.Favorites             // Favorite sessions, listed first
+	split    int                         // First row after the favorites section, -1 for none
+	tags     state.Tags                  // Session tags shown as chips
+	source   []string                    // Sessions in tmux order
+	sortBy   string                      // Active sort order, one of sessionSorts
+	frecency map[string]float64          // Visit scores from the jump list
 }
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			sessionInfoMsg delivers session details loaded in the background.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type sessionInfoMsg struct {
+	info map[string]tmux.SessionInfo
+}
+
+// switchFooterLines is the number of lines below the list used for status and prompts.
+const switchFooterLines = 2
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	 @Brief			NewSwitchMode creates a new SwitchMode instance.
@@ -34,11 +72,21 @@ type SwitchMode struct {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func NewSwitchMode(sessions []string) *SwitchMode {
-	return &SwitchMode{
-		sessions: sessions,
-		filtered: sessions,
+	favs, _ := state.LoadFavorites()
+	tags, _ := state.LoadTags()
+	prefs, _ := state.LoadPrefs()
+	history, _ := state.LoadHistory()
+	m := &SwitchMode{
 		input:    newSwitchInput(),
+		marked:   make(map[string]bool),
+		prompt:   newGroupPrompt(),
+		favs:     favs,
+		tags:     tags,
+		sortBy:   validSort(sessionSorts, prefs.SessionSort),
+		frecency: history.Frecency(),
 	}
+	m.setSessions(sessions)
+	return m
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -52,15 +100,47 @@ func NewSwitchMode(sessions []string) *SwitchMode {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *SwitchMode) Update(msg tea.Msg) (ModeStrategy, tea.Cmd) {
-	if key, ok := msg.(tea.KeyMsg); ok {
-		if next, cmd, done := m.handleKey(key); done {
+	switch t := msg.(type) {
+	case tea.KeyMsg:
+		if next, cmd, done := m.handleKey(t); done {
 			return next, cmd
 		}
+	case tea.MouseMsg:
+		next, cmd := m.handleMouse(t)
+		return next, tea.Batch(cmd, m.fetchVisibleGit())
+	case sessionInfoMsg:
+		m.info = t.info
+		m.setSessions(m.source)
+		return m, m.fetchVisibleGit()
+	case gitStatusMsg:
+		return m, nil
+	case SessionsChangedMsg:
+		m.refresh(t.Sessions)
+		return m, m.Init()
+	}
+	if m.prompting {
+		var cmd tea.Cmd
+		m.prompt, cmd = m.prompt.Update(msg)
+		return m, cmd
 	}
 	cmd := m.updateInput(msg)
 	m.applyFilter()
 	m.clampCursor()
-	return m, cmd
+	return m, tea.Batch(cmd, m.fetchVisibleGit())
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Init starts loading session details for sorting and git decorations.
+//
+//		@Return			tea.Cmd	Background command delivering a sessionInfoMsg
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) Init() tea.Cmd {
+	return func() tea.Msg {
+		info, _ := tmux.ListSessionInfo()
+		return sessionInfoMsg{info: info}
+	}
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -71,13 +151,24 @@ func (m *SwitchMode) Update(msg tea.Msg) (ModeStrategy, tea.Cmd) {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *SwitchMode) View() string {
-	q := m.query()
+	q, _ := favoritesQuery(m.query())
+	q, _ = tagQuery(q)
 	var b strings.Builder
-	for i, s := range m.filtered {
+	start, end := m.view.bounds(len(m.filtered), m.listHeight())
+	for i := start; i < end; i++ {
+		if i == m.split {
+			b.WriteString(renderFavoritesSeparator())
+		}
 		b.WriteString(m.rowPrefix(i))
-		b.WriteString(utils.HighlightMatches(s, q))
+		b.WriteString(m.markPrefix(m.filtered[i]))
+		b.WriteString(groupPrefix(m.filtered, i, m.info))
+		b.WriteString(favoritePrefix(m.favs.IsSession(m.filtered[i])))
+		b.WriteString(utils.HighlightMatches(m.filtered[i], q))
+		b.WriteString(renderGitStatus(m.info[m.filtered[i]].Path))
+		b.WriteString(renderTagChips(m.tags.Session(m.filtered[i])))
 		b.WriteByte('\n')
 	}
+	b.WriteString(m.renderStatus())
 	return b.String()
 }
 
@@ -95,7 +186,21 @@ func (m *SwitchMode) ModeName() string { return "SWITCH MODE" }
 //	@Brief			Reset clears the search input.
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func (m *SwitchMode) Reset() { m.input.Reset() }
+func (m *SwitchMode) Reset() {
+	m.input.Reset()
+	m.marked = make(map[string]bool)
+	m.status = ""
+	m.finishPrompt(false)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			CapturesInput reports whether the group prompt owns the keyboard.
+//
+//		@Return			bool	True while the group target is being typed
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) CapturesInput() bool { return m.prompting }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
@@ -140,6 +245,16 @@ func newSwitchInput() textinput.Model {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *SwitchMode) handleKey(k tea.KeyMsg) (ModeStrategy, tea.Cmd, bool) {
+	if m.prompting {
+		return m.handlePromptKey(k)
+	}
+	if slot, ok := slotKey(k.String(), ""); ok && m.query() == "" {
+		return m.openMark(slot)
+	}
+	if slot, ok := slotKey(k.String(), "alt+"); ok {
+		m.pinSelected(slot)
+		return m, nil, true
+	}
 	switch k.String() {
 	case "up", "k":
 		m.moveCursor(-1)
@@ -147,17 +262,259 @@ func (m *SwitchMode) handleKey(k tea.KeyMsg) (ModeStrategy, tea.Cmd, bool) {
 		m.moveCursor(1)
 	case "enter":
 		if m.hasSelection() {
-			tmux.AttachSession(m.filtered[m.cursor])
-			return m, tea.Quit, true
+			next, cmd := m.switchToSelected()
+			return next, cmd, true
 		}
-	case "d", "delete":
-		if m.hasSelection() {
-			// Does nothing in this exact moment in the universe
+	case " ":
+		if m.query() != "" {
+			return nil, nil, false
+		}
+		m.toggleMark()
+	case "ctrl+@":
+		m.toggleMark()
+	case "ctrl+a":
+		m.toggleMarkAll()
+	case "d":
+		if m.query() != "" {
+			return nil, nil, false
 		}
+		m.confirmKill()
+	case "delete":
+		m.confirmKill()
+	case "ctrl+x":
+		m.detachTargets()
+	case "ctrl+g":
+		m.startGroupPrompt()
+	case "ctrl+e":
+		m.snapshotTargets()
+	case "ctrl+f":
+		m.toggleFavorite()
+	case "ctrl+t":
+		m.startTagPrompt()
+	case "alt+enter":
+		return m.attachSelected(tmux.AttachOptions{DetachOthers: true})
+	case "alt+r":
+		return m.attachSelected(tmux.AttachOptions{ReadOnly: true})
+	case "alt+g":
+		return m.attachSelected(tmux.AttachOptions{Grouped: true})
+	case "alt+v":
+		return m, m.createGroupedView(), true
+	case "alt+s":
+		m.cycleSort()
+	case "ctrl+l":
+		return m.jump(session.Last)
+	case "ctrl+o":
+		return m.jump(session.Back)
+	case "ctrl+]":
+		return m.jump(session.Forward)
+	default:
+		return nil, nil, false
+	}
+	return m, nil, true
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			handlePromptKey processes keys while the prompt is active.
+//
+//		@Param			k		tea.KeyMsg		Keyboard message
+//
+//		@Return			ModeStrategy	Next mode (if changed)
+//		@Return			tea.Cmd			Command to execute
+//		@Return			bool			Whether key was handled
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) handlePromptKey(k tea.KeyMsg) (ModeStrategy, tea.Cmd, bool) {
+	switch k.String() {
+	case "enter":
+		m.finishPrompt(true)
+		return m, nil, true
+	case "esc":
+		m.finishPrompt(false)
+		return m, nil, true
 	}
 	return nil, nil, false
 }
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			handleMouse processes mouse input.
+//
+//		@Description	Wheel moves the cursor, click selects, double-click switches
+//
+//		@Param			msg		tea.MouseMsg	Mouse event relative to the mode's view
+//
+//		@Return			ModeStrategy	Next mode
+//		@Return			tea.Cmd			Command to execute
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) handleMouse(msg tea.MouseMsg) (ModeStrategy, tea.Cmd) {
+	if delta := wheelDelta(msg); delta != 0 {
+		m.moveCursor(delta)
+		return m, nil
+	}
+	if !isLeftClick(msg) {
+		return m, nil
+	}
+	line := msg.Y
+	if start, end := m.view.bounds(len(m.filtered), m.listHeight()); m.split >= start && m.split < end {
+		if line == m.split-start {
+			return m, nil
+		}
+		if line > m.split-start {
+			line--
+		}
+	}
+	row := m.view.offset + line
+	if line < 0 || row >= len(m.filtered) {
+		return m, nil
+	}
+	m.cursor = row
+	m.clampCursor()
+	if m.clicks.click(row) {
+		return m.switchToSelected()
+	}
+	return m, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			switchToSelected attaches to the selected session and quits.
+//
+//		@Description	Errors stay in the status line, like attachSelected
+//
+//		@Return			ModeStrategy	This mode
+//		@Return			tea.Cmd			Quit command on success
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) switchToSelected() (ModeStrategy, tea.Cmd) {
+	next, cmd, _ := m.attachSelected(tmux.AttachOptions{})
+	return next, cmd
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			createGroupedView adds a detached session grouped with the selected one.
+//
+//		@Description	The view can then be attached from another terminal and keeps its own
+//		@Description	current window; it stays until killed
+//
+//		@Return			tea.Cmd	Reload of the session details, nil on failure
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) createGroupedView() tea.Cmd {
+	if !m.hasSelection() {
+		return nil
+	}
+	target := m.filtered[m.cursor]
+	view, err := tmux.GroupedView(target)
+	if err != nil {
+		m.status = err.Error()
+		return nil
+	}
+	m.status = fmt.Sprintf("Created %s grouped with %s • tmux attach -t %s", view, target, view)
+	m.reloadSessions()
+	return m.Init()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			attachSelected attaches to the selected session with options and quits.
+//
+//		@Description	Errors such as a read-only attach inside tmux stay in the status line
+//
+//		@Param			opts	tmux.AttachOptions	Detach others, read-only or grouped view
+//
+//		@Return			ModeStrategy	This mode
+//		@Return			tea.Cmd			Quit command on success
+//		@Return			bool			Always true, the key is handled
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) attachSelected(opts tmux.AttachOptions) (ModeStrategy, tea.Cmd, bool) {
+	if !m.hasSelection() {
+		return m, nil, true
+	}
+	if err := session.AttachWith(m.filtered[m.cursor], opts); !attached(err) {
+		m.status = err.Error()
+		return m, nil, true
+	}
+	return m, tea.Quit, true
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			attached reports whether an attach went through.
+//
+//		@Description	A visit that could not be saved to the jump list does not undo it
+//
+//		@Param			err		error	Error of a session attach
+//
+//		@Return			bool	True if the client attached or switched
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func attached(err error) bool {
+	return err == nil || errors.Is(err, session.ErrJumpListNotSaved)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			jump attaches to a session from the jump list and quits.
+//
+//		@Description	Shows a status message instead when there is nowhere to jump
+//
+//		@Param			to		func() (string, error)	Jump list operation
+//
+//		@Return			ModeStrategy	This mode
+//		@Return			tea.Cmd			Quit command on success
+//		@Return			bool			Always true
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) jump(to func() (string, error)) (ModeStrategy, tea.Cmd, bool) {
+	if _, err := to(); !attached(err) {
+		m.status = err.Error()
+		return m, nil, true
+	}
+	return m, tea.Quit, true
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			openMark attaches to the session pinned to a slot and quits.
+//
+//		@Param			slot	int		Slot number from 1 to state.MaxMarks
+//
+//		@Return			ModeStrategy	This mode
+//		@Return			tea.Cmd			Quit command on success
+//		@Return			bool			Always true
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) openMark(slot int) (ModeStrategy, tea.Cmd, bool) {
+	return m.jump(func() (string, error) { return session.OpenMark(slot) })
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			pinSelected pins the selected session to a mark slot.
+//
+//		@Param			slot	int		Slot number from 1 to state.MaxMarks
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) pinSelected(slot int) {
+	if !m.hasSelection() {
+		return
+	}
+	name := m.filtered[m.cursor]
+	path := m.info[name].Path
+	if path == "" {
+		path, _ = tmux.SessionPath(name)
+	}
+	if err := session.PinMark(slot, state.Mark{Name: name, Path: path}); err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.status = fmt.Sprintf("Pinned %s to mark %d", name, slot)
+}
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	 @Brief			updateInput updates the search input field.
@@ -177,11 +534,11 @@ func (m *SwitchMode) updateInput(msg tea.Msg) tea.Cmd {
 //
 //	 @Brief			GetIcon returns the mode's icon.
 //
-//		@Return			string	Nerd font icon
+//		@Return			string	Icon from the active icon set
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *SwitchMode) GetIcon() string {
-	return "󰆧"
+	return styles.CurrentIcons.Switch
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -192,7 +549,48 @@ func (m *SwitchMode) GetIcon() string {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *SwitchMode) GetFooterText() string {
-	return "↑↓ navigate • ↵ switch • ⇥ cycle • ^N new • ^R rename • ? help • q quit"
+	if m.prompting {
+		return m.hint
+	}
+	return "↑↓ navigate • ↵ switch • ␣ mark • d kill • ⇥ cycle • ^N new • ^R rename • ? help • q quit"
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			GetShortcuts returns the shortcuts for the help dialog.
+//
+//		@Return			[]model.Shortcut	Switch mode shortcuts
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) GetShortcuts() []model.Shortcut {
+	return []model.Shortcut{
+		{Key: "↑ / k", Desc: "Move up"},
+		{Key: "↓ / j", Desc: "Move down"},
+		{Key: "Enter", Desc: "Switch to selected session"},
+		{Key: "Alt+Enter", Desc: "Switch and detach the session's other clients"},
+		{Key: "Alt+R", Desc: "Attach read-only (outside tmux)"},
+		{Key: "Alt+G", Desc: "Open a grouped view with its own current window"},
+		{Key: "Alt+V", Desc: "Create a detached grouped view, e.g. for a second monitor"},
+		{Key: "Click / Wheel", Desc: "Select session"},
+		{Key: "Double-click", Desc: "Switch to clicked session"},
+		{Key: "Space / Ctrl+Space", Desc: "Mark session (Space with empty search, Tab is taken by mode cycling)"},
+		{Key: "Ctrl+A", Desc: "Mark / unmark all filtered sessions"},
+		{Key: "d / Delete", Desc: "Kill marked or selected sessions, confirms several (d with empty search)"},
+		{Key: "Ctrl+X", Desc: "Detach clients of marked sessions"},
+		{Key: "Ctrl+G", Desc: "Move marked sessions into a group"},
+		{Key: "Ctrl+E", Desc: "Save marked sessions to a snapshot"},
+		{Key: "Ctrl+L", Desc: "Switch to previous session"},
+		{Key: "Ctrl+O", Desc: "Jump back in session history"},
+		{Key: "Ctrl+]", Desc: "Jump forward in session history"},
+		{Key: "Ctrl+F", Desc: "Toggle favorite"},
+		{Key: "*query", Desc: "Search favorites only"},
+		{Key: "Ctrl+T", Desc: "Edit session tags"},
+		{Key: "#tag", Desc: "Search sessions with tag"},
+		{Key: "Alt+S", Desc: "Cycle sort: name, activity, created, frecency, windows"},
+		{Key: "1-9", Desc: "Open mark slot (empty search, start with ' to search digits)"},
+		{Key: "Alt+1-9", Desc: "Pin session to mark slot"},
+		{Key: "type", Desc: "Search sessions"},
+	}
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -201,7 +599,117 @@ func (m *SwitchMode) GetFooterText() string {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *SwitchMode) applyFilter() {
-	m.filtered = utils.FuzzyFilter(m.sessions, m.query())
+	q, only := favoritesQuery(m.query())
+	q, tags := tagQuery(q)
+	matches := filterTagged(utils.FuzzyFilter(m.sessions, q), m.tags.Session, tags)
+	favorite := groupFavorites(matches, m.info, m.favs.IsSession)
+	m.filtered = favoritesFirst(matches, favorite, only)
+	m.split = favoritesSplit(len(m.filtered), func(i int) bool { return favorite(m.filtered[i]) })
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			startTagPrompt opens the prompt editing the selected session's tags.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) startTagPrompt() {
+	if !m.hasSelection() {
+		return
+	}
+	name := m.filtered[m.cursor]
+	m.startPrompt("Tags of "+name+": ", strings.Join(m.tags.Session(name), " "), func(value string) {
+		m.tags.SetSession(name, parseTagList(value))
+		if err := state.SaveTags(m.tags); err != nil {
+			m.status = err.Error()
+		}
+		m.applyFilter()
+		m.clampCursor()
+	})
+	m.prompt.Placeholder = "work oss infra..."
+	m.hint = "type tags • ↵ save • ⎋ cancel"
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			setSessions replaces the session list and applies the sort order.
+//
+//		@Param			sessions	[]string	Sessions in tmux order
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) setSessions(sessions []string) {
+	m.source = sessions
+	m.sessions = nestGroups(sortSessions(sessions, m.sortBy, m.info, m.frecency), m.info)
+	m.applyFilter()
+	m.clampCursor()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			refresh replaces the session list after a tmux notification.
+//
+//		@Description	Keeps the selected session under the cursor and drops marks of
+//		@Description	sessions that no longer exist
+//
+//		@Param			sessions	[]string	Current session names
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) refresh(sessions []string) {
+	selected := m.GetCurrentSession()
+	for s := range m.marked {
+		if !slices.Contains(sessions, s) {
+			delete(m.marked, s)
+		}
+	}
+	m.setSessions(sessions)
+	if i := slices.Index(m.filtered, selected); i >= 0 {
+		m.cursor = i
+		m.clampCursor()
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			cycleSort switches to the next sort order and remembers it.
+//
+//		@Description	Shows the error in the status line if the order cannot be saved
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) cycleSort() {
+	m.sortBy = nextSort(sessionSorts, m.sortBy)
+	if err := saveSortPref(func(p *state.Prefs) { p.SessionSort = m.sortBy }); err != nil {
+		m.status = err.Error()
+	}
+	m.setSessions(m.source)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SortLabel returns the active sort order for the header.
+//
+//		@Return			string	Sort order name
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) SortLabel() string { return m.sortBy }
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			toggleFavorite flags or unflags the selected session as favorite.
+//
+//		@Description	The cursor follows the session as it moves between sections
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) toggleFavorite() {
+	if !m.hasSelection() {
+		return
+	}
+	name := m.filtered[m.cursor]
+	m.favs.ToggleSession(name)
+	if err := state.SaveFavorites(m.favs); err != nil {
+		m.status = err.Error()
+	}
+	m.applyFilter()
+	m.cursor = max(slices.Index(m.filtered, name), 0)
+	m.clampCursor()
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -220,6 +728,21 @@ func (m *SwitchMode) clampCursor() {
 	} else if m.cursor >= n {
 		m.cursor = n - 1
 	}
+	m.view.follow(m.cursor, n, m.listHeight())
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			listHeight returns the number of session rows that fit on screen.
+//
+//		@Return			int		Visible row count
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) listHeight() int {
+	if m.split >= 0 {
+		return bodyHeight(switchFooterLines + 1)
+	}
+	return bodyHeight(switchFooterLines)
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -275,3 +798,61 @@ func (m *SwitchMode) rowPrefix(i int) string {
 	}
 	return "  "
 }
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			markPrefix returns the multi-selection column for a row.
+//
+//		@Description	The column is only shown while at least one session is marked
+//
+//		@Param			s		string	Session name
+//
+//		@Return			string	Mark icon, padding or empty string
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) markPrefix(s string) string {
+	if len(m.marked) == 0 {
+		return ""
+	}
+	icon := styles.CurrentIcons.Mark
+	if m.marked[s] {
+		return lipgloss.NewStyle().Foreground(styles.CurrentTheme.HighlightColor).Render(icon) + " "
+	}
+	return strings.Repeat(" ", lipgloss.Width(icon)+1)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			renderStatus renders the active prompt or the last status message.
+//
+//		@Return			string	Status lines (empty if there is nothing to show)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) renderStatus() string {
+	if m.prompting {
+		return "\n" + m.label + m.prompt.View()
+	}
+	if m.status == "" {
+		return ""
+	}
+	return "\n" + lipgloss.NewStyle().Foreground(styles.CurrentTheme.SecondaryColor).Render(styles.CurrentIcons.Text(m.status))
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			fetchVisibleGit loads git statuses of the sessions on screen.
+//
+//		@Return			tea.Cmd	Background git status command (nil if all cached)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *SwitchMode) fetchVisibleGit() tea.Cmd {
+	if m.info == nil {
+		return nil
+	}
+	start, end := m.view.bounds(len(m.filtered), m.listHeight())
+	dirs := make([]string, 0, end-start)
+	for _, s := range m.filtered[start:end] {
+		dirs = append(dirs, m.info[s].Path)
+	}
+	return fetchGitStatuses(dirs)
+}
@@ -0,0 +1,144 @@
+package modes
+
+import (
+	"hash/fnv"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tagPrefix marks a query word as a tag filter, e.g. "#infra api".
+const tagPrefix = "#"
+
+// tagChipColors are the background colors tag chips are hashed onto.
+var tagChipColors = []lipgloss.Color{"67", "71", "137", "133", "173", "73", "143", "97"}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			tagQuery splits a query into tag filters and the remaining text.
+//
+//		@Param			query	string	Search input without the favorites prefix
+//
+//		@Return			string		Text query
+//		@Return			[]string	Lowercased tags without the "#" prefix
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func tagQuery(query string) (string, []string) {
+	var words, tags []string
+	for _, w := range strings.Fields(query) {
+		if tag, ok := strings.CutPrefix(w, tagPrefix); ok {
+			if tag != "" {
+				tags = append(tags, strings.ToLower(tag))
+			}
+			continue
+		}
+		words = append(words, w)
+	}
+	return strings.Join(words, " "), tags
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			filterTagged keeps items carrying every wanted tag.
+//
+//		@Description	A wanted tag matches any tag it is a prefix of, so "#inf" finds "infra"
+//
+//		@Param			items	[]string				Items to filter
+//		@Param			tagsOf	func(string) []string	Tags of an item
+//		@Param			want	[]string				Lowercased tag filters
+//
+//		@Return			[]string	Matching items
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func filterTagged(items []string, tagsOf func(string) []string, want []string) []string {
+	if len(want) == 0 {
+		return items
+	}
+	out := make([]string, 0, len(items))
+	for _, it := range items {
+		if hasTags(tagsOf(it), want) {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			hasTags reports whether every wanted tag prefixes one of the tags.
+//
+//		@Param			tags	[]string	Tags of an item
+//		@Param			want	[]string	Lowercased tag filters
+//
+//		@Return			bool	True if all filters match
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func hasTags(tags, want []string) bool {
+	for _, w := range want {
+		if !slices.ContainsFunc(tags, func(t string) bool { return strings.HasPrefix(strings.ToLower(t), w) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			parseTagList parses tags entered as space or comma separated words.
+//
+//		@Param			input	string	Raw prompt input
+//
+//		@Return			[]string	Unique tags without a leading "#"
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func parseTagList(input string) []string {
+	var tags []string
+	for _, f := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		tag := strings.TrimPrefix(f, tagPrefix)
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			renderTagChips renders tags as colored chips after a row.
+//
+//		@Description	A tag keeps its color everywhere because the color is hashed from its name
+//
+//		@Param			tags	[]string	Tags to render
+//
+//		@Return			string	Chips preceded by a space, or nothing without tags
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func renderTagChips(tags []string) string {
+	var b strings.Builder
+	for _, tag := range tags {
+		h := fnv.New32a()
+		h.Write([]byte(strings.ToLower(tag)))
+		color := tagChipColors[h.Sum32()%uint32(len(tagChipColors))]
+		b.WriteByte(' ')
+		b.WriteString(lipgloss.NewStyle().Background(color).Foreground(lipgloss.Color("0")).Render(" " + tag + " "))
+	}
+	return b.String()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			newTagInput creates the input used to edit tags.
+//
+//		@Return			textinput.Model	Configured input field
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func newTagInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "work oss infra..."
+	ti.Prompt = ""
+	ti.CharLimit = 128
+	ti.Width = 30
+	return ti
+}
@@ -0,0 +1,80 @@
+package modes
+
+import "github.com/jkeresman01/tsm/styles"
+
+// layoutChromeLines is the number of container lines taken by padding, header and footer.
+const layoutChromeLines = 8
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			viewport tracks which slice of a list is currently visible.
+//
+//		@Description	Scrolls just enough to keep the cursor on screen
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type viewport struct {
+	offset int // Index of the first visible row
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			bodyHeight returns the number of lines available to a mode's view.
+//
+//		@Param			reserved	int		Lines the mode uses for its own headers and footers
+//
+//		@Return			int		Lines left for list rows (at least 1)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func bodyHeight(reserved int) int {
+	h := styles.CurrentTheme.ContainerHeight - layoutChromeLines - reserved
+	if h < 1 {
+		return 1
+	}
+	return h
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			follow scrolls the viewport so that the cursor is visible.
+//
+//		@Param			cursor	int		Currently selected index
+//		@Param			total	int		Number of rows in the list
+//		@Param			height	int		Number of visible rows
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (v *viewport) follow(cursor, total, height int) {
+	if cursor < v.offset {
+		v.offset = cursor
+	} else if cursor >= v.offset+height {
+		v.offset = cursor - height + 1
+	}
+	if limit := total - height; v.offset > limit {
+		v.offset = limit
+	}
+	if v.offset < 0 {
+		v.offset = 0
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			bounds returns the visible index range.
+//
+//		@Param			total	int		Number of rows in the list
+//		@Param			height	int		Number of visible rows
+//
+//		@Return			int		First visible index
+//		@Return			int		One past the last visible index
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (v *viewport) bounds(total, height int) (int, int) {
+	start := v.offset
+	if start > total {
+		start = total
+	}
+	end := start + height
+	if end > total {
+		end = total
+	}
+	return start, end
+}
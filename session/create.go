@@ -0,0 +1,139 @@
+package session
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/jkeresman01/tsm/state"
+	"github.com/jkeresman01/tsm/tmux"
+	"github.com/jkeresman01/tsm/utils"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			createSettings holds the configuration for new sessions.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type createSettings struct {
+	vars     map[string]string // Variables from the config's env
+	files    []string          // Env file names read from the session directory
+	commands map[string]string // Startup commands keyed by cleaned, expanded directory
+}
+
+// env is the session configuration set by Configure.
+var env createSettings
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Configure sets the environment and commands of sessions created by tsm.
+//
+//		@Param			vars		map[string]string	Global variables from the config
+//		@Param			files		[]string			Env file names read from the session directory
+//		@Param			commands	map[string]string	Startup commands keyed by search path or project
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Configure(vars map[string]string, files []string, commands map[string]string) {
+	env = createSettings{vars: vars, files: files, commands: make(map[string]string)}
+	for dir, command := range commands {
+		env.commands[filepath.Clean(utils.ExpandHome(dir))] = command
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Create creates a detached session with its project environment.
+//
+//		@Description	The session runs the startup command configured for the directory
+//
+//		@Param			name	string	Session name
+//		@Param			dir		string	Working directory of the session
+//
+//		@Return			error	Error if tmux fails to create the session
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Create(name, dir string) error {
+	return CreateWithCommand(name, dir, StartupCommand(dir))
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			CreateWithCommand creates a detached session running a given command.
+//
+//		@Description	Records the directory for create mode's frecency sort
+//
+//		@Param			name	string	Session name
+//		@Param			dir		string	Working directory of the session
+//		@Param			command	string	Shell command to run, empty for the default shell
+//
+//		@Return			error	Error if tmux fails to create the session
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func CreateWithCommand(name, dir, command string) error {
+	if err := tmux.CreateSessionWith(name, dir, tmux.SessionOptions{Env: Environment(dir), Command: command}); err != nil {
+		return err
+	}
+	v, _ := state.LoadDirVisits()
+	v.Visit(dir)
+	state.SaveDirVisits(v)
+	return nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			StartupCommand returns the command configured for a session directory.
+//
+//		@Description	The closest configured directory wins, so a project entry overrides
+//		@Description	the entry of the search path containing it
+//
+//		@Param			dir		string	Session directory
+//
+//		@Return			string	Shell command, empty if none is configured
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func StartupCommand(dir string) string {
+	if len(env.commands) == 0 || dir == "" {
+		return ""
+	}
+	for dir = filepath.Clean(dir); ; dir = filepath.Dir(dir) {
+		if command, ok := env.commands[dir]; ok {
+			return command
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return ""
+		}
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Environment returns the variables of a session created in a directory.
+//
+//		@Description	Config variables come first in name order, env files in the directory
+//		@Description	follow and override them
+//
+//		@Param			dir		string	Session directory
+//
+//		@Return			[]string	Variables as KEY=VALUE
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Environment(dir string) []string {
+	vars := make(map[string]string)
+	keys := make([]string, 0, len(env.vars))
+	for k := range env.vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		vars[k] = utils.EnvValue(env.vars[k], vars)
+	}
+	for _, k := range utils.LoadEnvFiles(dir, env.files, vars) {
+		if _, ok := env.vars[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, k+"="+vars[k])
+	}
+	return out
+}
@@ -0,0 +1,89 @@
+package session
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jkeresman01/tsm/state"
+	"github.com/jkeresman01/tsm/tmux"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			OpenMark attaches to the session pinned to a slot.
+//
+//		@Description	The session is created in the mark's directory if it does not exist
+//
+//		@Param			slot	int		Slot number from 1 to state.MaxMarks
+//
+//		@Return			string	Attached session
+//		@Return			error	Error for invalid or empty slots or tmux failures
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func OpenMark(slot int) (string, error) {
+	if slot < 1 || slot > state.MaxMarks {
+		return "", fmt.Errorf("mark slot must be between 1 and %d", state.MaxMarks)
+	}
+	marks, err := state.LoadMarks()
+	if err != nil {
+		return "", err
+	}
+	mark := marks[slot-1]
+	if mark.Empty() {
+		return "", fmt.Errorf("mark %d is empty", slot)
+	}
+	name := MarkSessionName(mark)
+	if !tmux.HasSession(name) {
+		if err := Create(name, mark.Path); err != nil {
+			return "", err
+		}
+	}
+	return name, Attach(name)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			PinMark stores a session in a slot, replacing its previous mark.
+//
+//		@Description	A mark already pointing at the same session is moved, not duplicated
+//
+//		@Param			slot	int			Slot number from 1 to state.MaxMarks
+//		@Param			mark	state.Mark	Session and directory to pin
+//
+//		@Return			error	Error for invalid slots or when the marks cannot be read or saved
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func PinMark(slot int, mark state.Mark) error {
+	if slot < 1 || slot > state.MaxMarks {
+		return fmt.Errorf("mark slot must be between 1 and %d", state.MaxMarks)
+	}
+	marks, err := state.LoadMarks()
+	if err != nil {
+		return fmt.Errorf("reading marks: %w", err)
+	}
+	for i, m := range marks {
+		if !m.Empty() && MarkSessionName(m) == MarkSessionName(mark) {
+			marks[i] = state.Mark{}
+		}
+	}
+	marks[slot-1] = mark
+	return state.SaveMarks(marks)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			MarkSessionName returns the session a mark refers to.
+//
+//		@Description	Marks without a name use their directory's base name
+//
+//		@Param			mark	state.Mark	Mark to resolve
+//
+//		@Return			string	tmux session name
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func MarkSessionName(mark state.Mark) string {
+	if mark.Name != "" {
+		return mark.Name
+	}
+	return tmux.SanitizeSessionName(filepath.Base(mark.Path))
+}
@@ -0,0 +1,171 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jkeresman01/tsm/state"
+	"github.com/jkeresman01/tsm/tmux"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			ErrNoSession is returned when the jump list has no session to go to.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+var ErrNoSession = errors.New("no session to jump to")
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			ErrJumpListNotSaved is returned when a session was attached but not recorded.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+var ErrJumpListNotSaved = errors.New("jump list not saved")
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Attach attaches to a session and records it in the jump list.
+//
+//		@Param			name	string	Session name
+//
+//		@Return			error	Error if tmux fails to attach
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Attach(name string) error {
+	return AttachWith(name, tmux.AttachOptions{})
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			AttachWith attaches to a session with options and records it in the jump list.
+//
+//		@Description	A grouped view is recorded under the target session's name. The visit
+//		@Description	is only saved once tmux attached, an unreadable jump list is not replaced
+//
+//		@Param			name	string				Session name
+//		@Param			opts	tmux.AttachOptions	Detach others, read-only or grouped view
+//
+//		@Return			error	Error if the jump list cannot be read or tmux fails to attach,
+//		@Return					ErrJumpListNotSaved if only saving the visit failed
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func AttachWith(name string, opts tmux.AttachOptions) error {
+	h, err := state.LoadHistory()
+	if err != nil {
+		return fmt.Errorf("reading jump list: %w", err)
+	}
+	h.Visit(tmux.CurrentSession())
+	h.Visit(name)
+	if err := tmux.AttachSessionWith(name, opts); err != nil {
+		return err
+	}
+	return saveHistory(h)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Last attaches to the previously attached session.
+//
+//		@Description	Uses tsm's history first, then tmux's #{client_last_session}
+//
+//		@Return			string	Attached session
+//		@Return			error	ErrNoSession if there is no previous session
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Last() (string, error) {
+	h, err := state.LoadHistory()
+	if err != nil {
+		return "", fmt.Errorf("reading jump list: %w", err)
+	}
+	name, ok := h.Previous(tmux.CurrentSession(), aliveSessions())
+	if !ok {
+		name = tmux.LastSession()
+	}
+	if name == "" {
+		return "", ErrNoSession
+	}
+	return name, Attach(name)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Back attaches to the previous entry of the jump list.
+//
+//		@Return			string	Attached session
+//		@Return			error	ErrNoSession at the start of the jump list
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Back() (string, error) {
+	return jump((*state.History).Back)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Forward attaches to the next entry of the jump list.
+//
+//		@Return			string	Attached session
+//		@Return			error	ErrNoSession at the end of the jump list
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Forward() (string, error) {
+	return jump((*state.History).Forward)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			jump moves through the jump list without recording a new visit.
+//
+//		@Param			move	func(*state.History, func(string) bool) (string, bool)	Direction
+//
+//		@Return			string	Attached session
+//		@Return			error	ErrNoSession if the list cannot move, ErrJumpListNotSaved if
+//		@Return					the session was attached but the new position was not saved
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func jump(move func(*state.History, func(string) bool) (string, bool)) (string, error) {
+	h, err := state.LoadHistory()
+	if err != nil {
+		return "", fmt.Errorf("reading jump list: %w", err)
+	}
+	name, ok := move(&h, aliveSessions())
+	if !ok {
+		return "", ErrNoSession
+	}
+	if err := tmux.AttachSession(name); err != nil {
+		return "", err
+	}
+	return name, saveHistory(h)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			saveHistory writes the jump list after a successful attach.
+//
+//		@Param			h	state.History	Updated jump list
+//
+//		@Return			error	ErrJumpListNotSaved wrapping the write error, nil on success
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func saveHistory(h state.History) error {
+	if err := state.SaveHistory(h); err != nil {
+		return fmt.Errorf("%w: %v", ErrJumpListNotSaved, err)
+	}
+	return nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			aliveSessions lists the sessions once for jump list lookups.
+//
+//		@Description	Avoids one 'tmux has-session' per history entry
+//
+//		@Return			func(string) bool	Reports whether a session exists
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func aliveSessions() func(string) bool {
+	sessions, _ := tmux.ListSessions()
+	return func(name string) bool {
+		return slices.Contains(sessions, name)
+	}
+}
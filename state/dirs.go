@@ -0,0 +1,83 @@
+package state
+
+import "path/filepath"
+
+const (
+	dirsFile  = "dirs.json"
+	dirsLimit = 500
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			DirVisits lists the directories sessions were created in, oldest first.
+//
+//		@Description	Ranks create mode's "frecency" sort order
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type DirVisits struct {
+	Entries []string `json:"entries"`
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			LoadDirVisits reads the directory visits from the state directory.
+//
+//		@Return			DirVisits	Stored visits, empty if none were saved
+//		@Return			error		Error if the file cannot be read
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func LoadDirVisits() (DirVisits, error) {
+	var v DirVisits
+	err := loadJSON(dirsFile, &v)
+	return v, err
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SaveDirVisits writes the directory visits to the state directory.
+//
+//		@Param			v	DirVisits	Visits to persist
+//
+//		@Return			error	Error if the file cannot be written
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func SaveDirVisits(v DirVisits) error {
+	return saveJSON(dirsFile, v)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Visit records a session being created in a directory.
+//
+//		@Description	Only the most recent dirsLimit visits are kept
+//
+//		@Param			dir		string	Session directory, cleaned before it is stored
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (v *DirVisits) Visit(dir string) {
+	if dir == "" {
+		return
+	}
+	v.Entries = append(v.Entries, filepath.Clean(dir))
+	if len(v.Entries) > dirsLimit {
+		v.Entries = v.Entries[len(v.Entries)-dirsLimit:]
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Frecency scores directories by how often and how recently they were visited.
+//
+//		@Description	Each visit counts more the more recent it is, as in History.Frecency
+//
+//		@Return			map[string]float64	Directory to score
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (v *DirVisits) Frecency() map[string]float64 {
+	scores := make(map[string]float64)
+	n := float64(len(v.Entries))
+	for i, dir := range v.Entries {
+		scores[dir] += float64(i+1) / n
+	}
+	return scores
+}
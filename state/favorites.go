@@ -0,0 +1,109 @@
+package state
+
+import "slices"
+
+const favoritesFile = "favorites.json"
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Favorites lists the sessions and directories flagged as favorite.
+//
+//		@Description	Favorites are shown first in switch and create mode
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Favorites struct {
+	Sessions []string `json:"sessions"`
+	Dirs     []string `json:"dirs"`
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			LoadFavorites reads the favorites from the state directory.
+//
+//		@Return			Favorites	Stored favorites, empty if none were saved
+//		@Return			error		Error if the file cannot be read
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func LoadFavorites() (Favorites, error) {
+	var f Favorites
+	err := loadJSON(favoritesFile, &f)
+	return f, err
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SaveFavorites writes the favorites to the state directory.
+//
+//		@Param			f	Favorites	Favorites to persist
+//
+//		@Return			error	Error if the file cannot be written
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func SaveFavorites(f Favorites) error {
+	return saveJSON(favoritesFile, f)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			IsSession reports whether a session is a favorite.
+//
+//		@Param			name	string	Session name
+//
+//		@Return			bool	True for favorite sessions
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (f *Favorites) IsSession(name string) bool {
+	return slices.Contains(f.Sessions, name)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			IsDir reports whether a directory is a favorite.
+//
+//		@Param			dir		string	Directory path
+//
+//		@Return			bool	True for favorite directories
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (f *Favorites) IsDir(dir string) bool {
+	return slices.Contains(f.Dirs, dir)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ToggleSession flags or unflags a session as favorite.
+//
+//		@Param			name	string	Session name
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (f *Favorites) ToggleSession(name string) {
+	f.Sessions = toggle(f.Sessions, name)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ToggleDir flags or unflags a directory as favorite.
+//
+//		@Param			dir		string	Directory path
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (f *Favorites) ToggleDir(dir string) {
+	f.Dirs = toggle(f.Dirs, dir)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			toggle adds a value to a list or removes it if present.
+//
+//		@Param			list	[]string	Current values
+//		@Param			value	string		Value to toggle
+//
+//		@Return			[]string	Updated values
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func toggle(list []string, value string) []string {
+	if i := slices.Index(list, value); i >= 0 {
+		return slices.Delete(list, i, i+1)
+	}
+	return append(list, value)
+}
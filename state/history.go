@@ -0,0 +1,187 @@
+package state
+
+const (
+	historyFile  = "history.json"
+	historyLimit = 100
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			History is the jump list of attached sessions.
+//
+//		@Description	Index points at the current entry, entries after it can be revisited
+//		@Description	with Forward until a new session is visited
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type History struct {
+	Entries []string `json:"entries"`
+	Index   int      `json:"index"`
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			LoadHistory reads the jump list from the state directory.
+//
+//		@Return			History	Stored jump list, empty if none was saved
+//		@Return			error	Error if the file cannot be read
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func LoadHistory() (History, error) {
+	var h History
+	if err := loadJSON(historyFile, &h); err != nil {
+		return History{}, err
+	}
+	h.clampIndex()
+	return h, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SaveHistory writes the jump list to the state directory.
+//
+//		@Param			h	History	Jump list to persist
+//
+//		@Return			error	Error if the file cannot be written
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func SaveHistory(h History) error {
+	return saveJSON(historyFile, h)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Visit records a session as the current entry.
+//
+//		@Description	Entries after the current one are discarded, like a browser history
+//
+//		@Param			name	string	Attached session
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (h *History) Visit(name string) {
+	if name == "" || h.Current() == name {
+		return
+	}
+	if len(h.Entries) > 0 {
+		h.Entries = h.Entries[:h.Index+1]
+	}
+	h.Entries = append(h.Entries, name)
+	if len(h.Entries) > historyLimit {
+		h.Entries = h.Entries[len(h.Entries)-historyLimit:]
+	}
+	h.Index = len(h.Entries) - 1
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Current returns the current entry.
+//
+//		@Return			string	Current session, empty if the history is empty
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (h *History) Current() string {
+	if len(h.Entries) == 0 {
+		return ""
+	}
+	return h.Entries[h.Index]
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Previous returns the most recent entry that differs from current.
+//
+//		@Param			current	string				Session to skip, empty outside tmux
+//		@Param			alive	func(string) bool	Reports whether a session still exists
+//
+//		@Return			string	Previous session
+//		@Return			bool	False if there is none
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (h *History) Previous(current string, alive func(string) bool) (string, bool) {
+	for i := h.Index; i >= 0 && i < len(h.Entries); i-- {
+		if name := h.Entries[i]; name != current && alive(name) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Back moves to the closest earlier entry that still exists.
+//
+//		@Param			alive	func(string) bool	Reports whether a session still exists
+//
+//		@Return			string	Session to attach
+//		@Return			bool	False if there is no earlier entry
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (h *History) Back(alive func(string) bool) (string, bool) {
+	return h.step(-1, alive)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Forward moves to the closest later entry that still exists.
+//
+//		@Param			alive	func(string) bool	Reports whether a session still exists
+//
+//		@Return			string	Session to attach
+//		@Return			bool	False if there is no later entry
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (h *History) Forward(alive func(string) bool) (string, bool) {
+	return h.step(1, alive)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			step moves the index in a direction, skipping dead sessions.
+//
+//		@Param			dir		int					-1 for back, 1 for forward
+//		@Param			alive	func(string) bool	Reports whether a session still exists
+//
+//		@Return			string	Session at the new index
+//		@Return			bool	False if the index did not move
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (h *History) step(dir int, alive func(string) bool) (string, bool) {
+	for i := h.Index + dir; i >= 0 && i < len(h.Entries); i += dir {
+		if alive(h.Entries[i]) {
+			h.Index = i
+			return h.Entries[i], true
+		}
+	}
+	return "", false
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Frecency scores sessions by how often and how recently they were visited.
+//
+//		@Description	Each visit counts more the closer it is to the end of the jump list
+//
+//		@Return			map[string]float64	Session name to score
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (h *History) Frecency() map[string]float64 {
+	scores := make(map[string]float64)
+	n := float64(len(h.Entries))
+	for i, name := range h.Entries {
+		scores[name] += float64(i+1) / n
+	}
+	return scores
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			clampIndex keeps the index inside the entries after loading.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (h *History) clampIndex() {
+	if h.Index >= len(h.Entries) {
+		h.Index = len(h.Entries) - 1
+	}
+	if h.Index < 0 {
+		h.Index = 0
+	}
+}
@@ -0,0 +1,73 @@
+package state
+
+const (
+	marksFile = "marks.json"
+
+	// MaxMarks is the number of mark slots, addressed as 1 to MaxMarks.
+	MaxMarks = 9
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Mark pins a session to a numbered slot.
+//
+//		@Description	Path is kept so the session can be recreated when it no longer exists
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Mark struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Empty reports whether the slot holds no mark.
+//
+//		@Return			bool	True for an unused slot
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m Mark) Empty() bool {
+	return m.Name == "" && m.Path == ""
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			LoadMarks reads the mark slots from the state directory.
+//
+//		@Return			[]Mark	Exactly MaxMarks slots, index 0 is slot 1
+//		@Return			error	Error if the file cannot be read
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func LoadMarks() ([]Mark, error) {
+	var marks []Mark
+	err := loadJSON(marksFile, &marks)
+	return normalizeMarks(marks), err
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SaveMarks writes the mark slots to the state directory.
+//
+//		@Param			marks	[]Mark	Slots to persist
+//
+//		@Return			error	Error if the file cannot be written
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func SaveMarks(marks []Mark) error {
+	return saveJSON(marksFile, normalizeMarks(marks))
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			normalizeMarks pads or truncates marks to MaxMarks slots.
+//
+//		@Param			marks	[]Mark	Stored slots
+//
+//		@Return			[]Mark	Slots of length MaxMarks
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func normalizeMarks(marks []Mark) []Mark {
+	out := make([]Mark, MaxMarks)
+	copy(out, marks)
+	return out
+}
@@ -0,0 +1,40 @@
+package state
+
+const prefsFile = "prefs.json"
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			Prefs holds UI choices remembered between runs.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Prefs struct {
+	SessionSort string `json:"session_sort,omitempty"`
+	DirSort     string `json:"dir_sort,omitempty"`
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			LoadPrefs reads the preferences from the state directory.
+//
+//		@Return			Prefs	Stored preferences, zero values if none were saved
+//		@Return			error	Error if the file cannot be read
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func LoadPrefs() (Prefs, error) {
+	var p Prefs
+	err := loadJSON(prefsFile, &p)
+	return p, err
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SavePrefs writes the preferences to the state directory.
+//
+//		@Param			p	Prefs	Preferences to persist
+//
+//		@Return			error	Error if the file cannot be written
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func SavePrefs(p Prefs) error {
+	return saveJSON(prefsFile, p)
+}
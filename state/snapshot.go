@@ -0,0 +1,57 @@
+package state
+
+import (
+	"path/filepath"
+	"time"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			Snapshot records a set of sessions so they can be recreated later.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Snapshot struct {
+	Created  time.Time         `json:"created"`
+	Sessions []SessionSnapshot `json:"sessions"`
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			SessionSnapshot records a single session and its windows.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type SessionSnapshot struct {
+	Name    string           `json:"name"`
+	Path    string           `json:"path"`
+	Windows []WindowSnapshot `json:"windows"`
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			WindowSnapshot records a window's name and working directory.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type WindowSnapshot struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SaveSnapshot writes a snapshot to the snapshots directory.
+//
+//		@Description	Files are named after the snapshot's creation time
+//
+//		@Param			snap	Snapshot	Snapshot to persist
+//
+//		@Return			string	Path of the written file
+//		@Return			error	Error if the file cannot be written
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func SaveSnapshot(snap Snapshot) (string, error) {
+	name := filepath.Join("snapshots", snap.Created.Format("20060102-150405")+".json")
+	if err := saveJSON(name, snap); err != nil {
+		return "", err
+	}
+	return Path(name)
+}
@@ -0,0 +1,116 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const (
+	dirPermission  = 0755
+	filePermission = 0644
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Dir returns the directory where tsm keeps persistent state.
+//
+//		@Description	Uses $XDG_STATE_HOME/tsm, falling back to ~/.local/state/tsm
+//		@Description	The directory is created if it does not exist
+//
+//		@Return			string	State directory path
+//		@Return			error	Error if the directory cannot be determined or created
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Dir() (string, error) {
+	base := os.Getenv("XDG_STATE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		base = filepath.Join(home, ".local", "state")
+	}
+
+	dir := filepath.Join(base, "tsm")
+	if err := os.MkdirAll(dir, dirPermission); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Path returns the path of a file inside the state directory.
+//
+//		@Param			elem	...string	Path elements relative to the state directory
+//
+//		@Return			string	Joined path
+//		@Return			error	Error if the state directory is unavailable
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Path(elem ...string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(append([]string{dir}, elem...)...), nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			loadJSON reads a JSON state file into v.
+//
+//		@Description	A missing file is not an error and leaves v untouched
+//
+//		@Param			name	string	File name inside the state directory
+//		@Param			v		any		Destination value
+//
+//		@Return			error	Error if the file exists but cannot be read or parsed
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func loadJSON(name string, v any) error {
+	path, err := Path(name)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			saveJSON writes v as indented JSON into the state directory.
+//
+//		@Param			name	string	File name inside the state directory
+//		@Param			v		any		Value to persist
+//
+//		@Return			error	Error if the file cannot be written
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func saveJSON(name string, v any) error {
+	path, err := Path(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), dirPermission); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, filePermission)
+}
@@ -0,0 +1,117 @@
+package state
+
+const tagsFile = "tags.json"
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			Tags holds the labels attached to sessions and directories.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Tags struct {
+	Sessions map[string][]string `json:"sessions"`
+	Dirs     map[string][]string `json:"dirs"`
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			LoadTags reads the tag metadata from the state directory.
+//
+//		@Return			Tags	Stored tags, empty if none were saved
+//		@Return			error	Error if the file cannot be read
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func LoadTags() (Tags, error) {
+	var t Tags
+	err := loadJSON(tagsFile, &t)
+	return t, err
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SaveTags writes the tag metadata to the state directory.
+//
+//		@Param			t	Tags	Tags to persist
+//
+//		@Return			error	Error if the file cannot be written
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func SaveTags(t Tags) error {
+	return saveJSON(tagsFile, t)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Session returns the tags of a session.
+//
+//		@Param			name	string	Session name
+//
+//		@Return			[]string	Tags in the order they were entered
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (t *Tags) Session(name string) []string {
+	return t.Sessions[name]
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Dir returns the tags of a directory.
+//
+//		@Param			dir		string	Directory path
+//
+//		@Return			[]string	Tags in the order they were entered
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (t *Tags) Dir(dir string) []string {
+	return t.Dirs[dir]
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SetSession replaces the tags of a session.
+//
+//		@Description	An empty list removes the session from the metadata
+//
+//		@Param			name	string		Session name
+//		@Param			tags	[]string	New tags
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (t *Tags) SetSession(name string, tags []string) {
+	t.Sessions = setTags(t.Sessions, name, tags)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SetDir replaces the tags of a directory.
+//
+//		@Description	An empty list removes the directory from the metadata
+//
+//		@Param			dir		string		Directory path
+//		@Param			tags	[]string	New tags
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (t *Tags) SetDir(dir string, tags []string) {
+	t.Dirs = setTags(t.Dirs, dir, tags)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			setTags stores or removes the tags of a key.
+//
+//		@Param			m		map[string][]string	Tag map, may be nil
+//		@Param			key		string				Session name or directory
+//		@Param			tags	[]string			New tags
+//
+//		@Return			map[string][]string	Updated map
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func setTags(m map[string][]string, key string, tags []string) map[string][]string {
+	if len(tags) == 0 {
+		delete(m, key)
+		return m
+	}
+	if m == nil {
+		m = make(map[string][]string)
+	}
+	m[key] = tags
+	return m
+}
@@ -0,0 +1,116 @@
+package styles
+
+import "github.com/charmbracelet/lipgloss"
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			bundledSpec returns the specification of a bundled theme.
+//
+//		@Param			name	string	Theme name (dark, light, catppuccin, gruvbox, tokyonight, nord)
+//
+//		@Return			themeSpec	Theme specification (defaults to dark)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func bundledSpec(name string) themeSpec {
+	switch name {
+	case "light":
+		return lightSpec()
+	case "catppuccin":
+		return paletteSpec(catppuccinPalette())
+	case "gruvbox":
+		return paletteSpec(gruvboxPalette())
+	case "tokyonight":
+		return paletteSpec(tokyonightPalette())
+	case "nord":
+		return paletteSpec(nordPalette())
+	default:
+		return darkSpec()
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			paletteSpec builds a dark-sized specification around a palette.
+//
+//		@Param			p	themePalette	Color palette
+//
+//		@Return			themeSpec	Specification with dark theme sizes and rounded borders
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func paletteSpec(p themePalette) themeSpec {
+	spec := darkSpec()
+	spec.palette = p
+	return spec
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			catppuccinPalette returns the Catppuccin Mocha palette.
+//
+//		@Return			themePalette	Catppuccin color palette
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func catppuccinPalette() themePalette {
+	return themePalette{
+		border:    lipgloss.Color("#CBA6F7"),
+		secondary: lipgloss.Color("#6C7086"),
+		dimBG:     lipgloss.Color("#181825"),
+		accent:    lipgloss.Color("#89B4FA"),
+		highlight: lipgloss.Color("#F5C2E7"),
+		match:     lipgloss.Color("#F9E2AF"),
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			gruvboxPalette returns the Gruvbox dark palette.
+//
+//		@Return			themePalette	Gruvbox color palette
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func gruvboxPalette() themePalette {
+	return themePalette{
+		border:    lipgloss.Color("#D79921"),
+		secondary: lipgloss.Color("#928374"),
+		dimBG:     lipgloss.Color("#1D2021"),
+		accent:    lipgloss.Color("#FABD2F"),
+		highlight: lipgloss.Color("#FE8019"),
+		match:     lipgloss.Color("#B8BB26"),
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			tokyonightPalette returns the Tokyo Night palette.
+//
+//		@Return			themePalette	Tokyo Night color palette
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func tokyonightPalette() themePalette {
+	return themePalette{
+		border:    lipgloss.Color("#7AA2F7"),
+		secondary: lipgloss.Color("#565F89"),
+		dimBG:     lipgloss.Color("#16161E"),
+		accent:    lipgloss.Color("#7DCFFF"),
+		highlight: lipgloss.Color("#BB9AF7"),
+		match:     lipgloss.Color("#FF9E64"),
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			nordPalette returns the Nord palette.
+//
+//		@Return			themePalette	Nord color palette
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func nordPalette() themePalette {
+	return themePalette{
+		border:    lipgloss.Color("#88C0D0"),
+		secondary: lipgloss.Color("#4C566A"),
+		dimBG:     lipgloss.Color("#2E3440"),
+		accent:    lipgloss.Color("#81A1C1"),
+		highlight: lipgloss.Color("#B48EAD"),
+		match:     lipgloss.Color("#EBCB8B"),
+	}
+}
@@ -0,0 +1,98 @@
+package styles
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jkeresman01/tsm/config"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			customSpec converts a user-defined theme into a specification.
+//
+//		@Description	Starts from the bundled base theme and overrides every field that is set
+//
+//		@Param			tc	config.ThemeConfig	User-defined theme
+//
+//		@Return			themeSpec	Resulting theme specification
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func customSpec(tc config.ThemeConfig) themeSpec {
+	spec := bundledSpec(normalizeMode(tc.Base))
+
+	overrideColor(&spec.palette.border, tc.Palette.Border)
+	overrideColor(&spec.palette.secondary, tc.Palette.Secondary)
+	overrideColor(&spec.palette.dimBG, tc.Palette.DimBackground)
+	overrideColor(&spec.palette.accent, tc.Palette.Accent)
+	overrideColor(&spec.palette.highlight, tc.Palette.Highlight)
+	overrideColor(&spec.palette.match, tc.Palette.Match)
+
+	overrideSize(&spec.sizes.leftPanel, tc.Sizes.LeftPanel)
+	overrideSize(&spec.sizes.rightPanel, tc.Sizes.RightPanel)
+	overrideSize(&spec.sizes.containerHeight, tc.Sizes.ContainerHeight)
+
+	if border, ok := borderByName(tc.Border); ok {
+		spec.border = border
+	}
+
+	return spec
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			overrideColor replaces a palette color when a value is given.
+//
+//		@Param			dst		*lipgloss.Color	Color to override
+//		@Param			value	string			ANSI number or hex color, empty to keep
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func overrideColor(dst *lipgloss.Color, value string) {
+	if v := strings.TrimSpace(value); v != "" {
+		*dst = lipgloss.Color(v)
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			overrideSize replaces a dimension when a positive value is given.
+//
+//		@Param			dst		*int	Dimension to override
+//		@Param			value	int		New dimension, zero or negative to keep
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func overrideSize(dst *int, value int) {
+	if value > 0 {
+		*dst = value
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			borderByName resolves a border shape from its config name.
+//
+//		@Param			name	string	"rounded", "normal", "thick", "double", "block" or "hidden"
+//
+//		@Return			lipgloss.Border	Border shape
+//		@Return			bool			False if the name is empty or unknown
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func borderByName(name string) (lipgloss.Border, bool) {
+	switch strings.TrimSpace(strings.ToLower(name)) {
+	case "rounded":
+		return lipgloss.RoundedBorder(), true
+	case "normal":
+		return lipgloss.NormalBorder(), true
+	case "thick":
+		return lipgloss.ThickBorder(), true
+	case "double":
+		return lipgloss.DoubleBorder(), true
+	case "block":
+		return lipgloss.BlockBorder(), true
+	case "hidden":
+		return lipgloss.HiddenBorder(), true
+	default:
+		return lipgloss.Border{}, false
+	}
+}
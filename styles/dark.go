@@ -10,21 +10,21 @@ import "github.com/charmbracelet/lipgloss"
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func DarkTheme() Theme {
-	p := darkPalette()
-	s := darkSizes()
+	return buildTheme(darkSpec())
+}
 
-	return Theme{
-		BorderColor:      p.border,
-		SecondaryColor:   p.secondary,
-		LeftPanelWidth:   s.leftPanel,
-		RightPanelWidth:  s.rightPanel,
-		ContainerHeight:  s.containerHeight,
-		OuterStyle:       outerStyle(p, s),
-		HeaderStyle:      headerStyle(p),
-		FooterStyle:      footerStyle(p),
-		DimmedBackground: dimStyle(p),
-		ListStyle:        listStyle(s),
-		PreviewStyle:     previewStyle(s),
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			darkSpec returns the specification of the dark theme.
+//
+//		@Return			themeSpec	Dark palette, sizes and border shape
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func darkSpec() themeSpec {
+	return themeSpec{
+		palette: darkPalette(),
+		sizes:   darkSizes(),
+		border:  lipgloss.RoundedBorder(),
 	}
 }
 
@@ -40,6 +40,7 @@ func darkPalette() themePalette {
 		border:    lipgloss.Color("15"),
 		secondary: lipgloss.Color("241"),
 		dimBG:     lipgloss.Color("236"),
+		match:     lipgloss.Color("212"),
 	}
 }
 
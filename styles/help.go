@@ -6,6 +6,7 @@ var (
 	HelpKeyStyle   = helpKey()
 	HelpDescStyle  = helpDesc()
 	HelpTitleStyle = helpTitle()
+	HelpGroupStyle = helpGroup()
 	HelpBoxStyle   = helpBox()
 )
 
@@ -20,6 +21,7 @@ func RefreshHelpStyles() {
 	HelpKeyStyle = helpKey()
 	HelpDescStyle = helpDesc()
 	HelpTitleStyle = helpTitle()
+	HelpGroupStyle = helpGroup()
 	HelpBoxStyle = helpBox()
 }
 
@@ -47,14 +49,14 @@ func secondary() lipgloss.Style {
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
-//	 @Brief			bordered creates a style with rounded borders.
+//	 @Brief			bordered creates a style with the theme's border shape.
 //
 //		@Return			lipgloss.Style	Bordered style
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func bordered() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(CurrentTheme.Border).
 		BorderForeground(CurrentTheme.BorderColor)
 }
 
@@ -91,6 +93,17 @@ func helpTitle() lipgloss.Style {
 	return accent().MarginBottom(1).Bold(true).Align(lipgloss.Center)
 }
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			helpGroup creates the style for section headings in help.
+//
+//		@Return			lipgloss.Style	Group heading style (bold, underlined)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func helpGroup() lipgloss.Style {
+	return accent().Bold(true).Underline(true)
+}
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	 @Brief			helpBox creates the style for help dialog container.
@@ -0,0 +1,227 @@
+package styles
+
+import (
+	"os"
+	"strings"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			IconSet defines the glyphs used across the UI.
+//
+//		@Description	Variants exist for Nerd Fonts, plain Unicode and 7-bit ASCII terminals
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type IconSet struct {
+	Title      string // Application title glyph
+	Switch     string // Switch mode indicator
+	Create     string // Create mode indicator
+	Rename     string // Rename mode indicator
+	Marks      string // Marks mode indicator
+	Servers    string // Servers mode indicator
+	Search     string // Search bar prefix
+	Pointer    string // Selected row prefix
+	Folder     string // Directory row icon
+	FolderPath string // Full path line under the selected directory
+	Chevron    string // Marker after the selected directory name
+	Mark       string // Multi-selection marker
+	Favorite   string // Favorite session or directory marker
+	Expanded   string // Expanded group header
+	Collapsed  string // Collapsed group header
+	Nested     string // Session nested under another session of its group
+	Sort       string // Sort order indicator in the header
+	Branch     string // Git branch decoration
+	Dirty      string // Git uncommitted changes decoration
+	Ahead      string // Git commits ahead of upstream
+	Behind     string // Git commits behind upstream
+
+	glyphs *strings.Replacer // Replaces the Unicode symbols of UI text, nil keeps them
+}
+
+var CurrentIcons = unicodeIcons()
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Text adapts UI text such as footers and key names to the icon set.
+//
+//		@Description	Footers are written with Unicode key symbols (↑↓ ↵ ⎋ ⇥ ␣ •),
+//		@Description	the ASCII set spells them out
+//
+//		@Param			s	string	Text using Unicode symbols
+//
+//		@Return			string	Text displayable with the icon set
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (i IconSet) Text(s string) string {
+	if i.glyphs == nil {
+		return s
+	}
+	return i.glyphs.Replace(s)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			InitIcons selects the icon set to use.
+//
+//		@Description	"auto" or an empty name detects the set from the environment
+//
+//		@Param			name	string	Icon set name ("nerd", "unicode", "ascii" or "auto")
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func InitIcons(name string) {
+	CurrentIcons = pickIcons(strings.TrimSpace(strings.ToLower(name)))
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			pickIcons returns the icon set for a normalized name.
+//
+//		@Param			name	string	Icon set name
+//
+//		@Return			IconSet	Selected icon set
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func pickIcons(name string) IconSet {
+	switch name {
+	case "nerd":
+		return nerdIcons()
+	case "unicode":
+		return unicodeIcons()
+	case "ascii":
+		return asciiIcons()
+	default:
+		return pickIcons(detectIcons())
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			detectIcons guesses the richest icon set the terminal can display.
+//
+//		@Description	Linux console, dumb and vt* terminals get ASCII, everything else plain
+//		@Description	Unicode. $TERM says nothing about the font, so Nerd Font glyphs are
+//		@Description	only used when configured
+//
+//		@Return			string	Icon set name
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func detectIcons() string {
+	term := strings.ToLower(os.Getenv("TERM"))
+	if term == "" || term == "dumb" || term == "linux" || term == "ansi" || strings.HasPrefix(term, "vt") {
+		return "ascii"
+	}
+	return "unicode"
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			nerdIcons returns the Nerd Font icon set.
+//
+//		@Return			IconSet	Nerd Font glyphs
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func nerdIcons() IconSet {
+	return IconSet{
+		Title:      "󱎫",
+		Switch:     "󰆧",
+		Create:     "󰐕",
+		Rename:     "󰑕",
+		Marks:      "󰃀",
+		Servers:    "󰒋",
+		Search:     "🔍",
+		Pointer:    "▶",
+		Folder:     "󰉋",
+		FolderPath: "󰉖",
+		Chevron:    "󰄾",
+		Mark:       "",
+		Favorite:   "",
+		Expanded:   "",
+		Collapsed:  "",
+		Nested:     "└",
+		Sort:       "󰒺",
+		Branch:     "",
+		Dirty:      "",
+		Ahead:      "⇡",
+		Behind:     "⇣",
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			unicodeIcons returns an icon set using standard Unicode symbols.
+//
+//		@Return			IconSet	Unicode glyphs available in common fonts
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func unicodeIcons() IconSet {
+	return IconSet{
+		Title:      "◆",
+		Switch:     "⇄",
+		Create:     "✚",
+		Rename:     "✎",
+		Marks:      "⚑",
+		Servers:    "⧉",
+		Search:     "⌕",
+		Pointer:    "▶",
+		Folder:     "▪",
+		FolderPath: "↳",
+		Chevron:    "»",
+		Mark:       "●",
+		Favorite:   "★",
+		Expanded:   "▾",
+		Collapsed:  "▸",
+		Nested:     "└",
+		Sort:       "↕",
+		Branch:     "⎇",
+		Dirty:      "±",
+		Ahead:      "↑",
+		Behind:     "↓",
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			asciiIcons returns an icon set restricted to 7-bit ASCII.
+//
+//		@Return			IconSet	ASCII glyphs
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func asciiIcons() IconSet {
+	return IconSet{
+		Title:      "#",
+		Switch:     "<>",
+		Create:     "+",
+		Rename:     "~",
+		Marks:      "=",
+		Servers:    "@",
+		Search:     "/",
+		Pointer:    ">",
+		Folder:     "-",
+		FolderPath: "`-",
+		Chevron:    "<",
+		Mark:       "*",
+		Favorite:   "^",
+		Expanded:   "v",
+		Collapsed:  ">",
+		Nested:     "`",
+		Sort:       "~",
+		Branch:     "@",
+		Dirty:      "*",
+		Ahead:      "+",
+		Behind:     "-",
+		glyphs: strings.NewReplacer(
+			"↑↓", "up/down",
+			"↑", "up",
+			"↓", "down",
+			"←", "left",
+			"→", "->",
+			"↵", "enter",
+			"⎋", "esc",
+			"⇥", "tab",
+			"␣", "space",
+			"•", "|",
+			"—", "-",
+			"─", "-",
+		),
+	}
+}
@@ -10,23 +10,21 @@ import "github.com/charmbracelet/lipgloss"
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func LightTheme() Theme {
-	p := lightPalette()
-	s := lightSizes()
+	return buildTheme(lightSpec())
+}
 
-	return Theme{
-		BorderColor:      p.border,
-		SecondaryColor:   p.secondary,
-		AccentColor:      p.accent,
-		HighlightColor:   p.highlight,
-		LeftPanelWidth:   s.leftPanel,
-		RightPanelWidth:  s.rightPanel,
-		ContainerHeight:  s.containerHeight,
-		OuterStyle:       outerStyle(p, s),
-		HeaderStyle:      headerStyle(p),
-		FooterStyle:      footerStyle(p),
-		DimmedBackground: dimStyle(p),
-		ListStyle:        listStyle(s),
-		PreviewStyle:     previewStyle(s),
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			lightSpec returns the specification of the light theme.
+//
+//		@Return			themeSpec	Light palette, sizes and border shape
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func lightSpec() themeSpec {
+	return themeSpec{
+		palette: lightPalette(),
+		sizes:   lightSizes(),
+		border:  lipgloss.RoundedBorder(),
 	}
 }
 
@@ -44,6 +42,7 @@ func lightPalette() themePalette {
 		dimBG:     lipgloss.Color("#F1F5F9"),
 		accent:    lipgloss.Color("#8B5CF6"),
 		highlight: lipgloss.Color("#A855F7"),
+		match:     lipgloss.Color("212"),
 	}
 }
 
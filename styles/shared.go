@@ -28,20 +28,64 @@ type themePalette struct {
 	dimBG     lipgloss.Color
 	accent    lipgloss.Color
 	highlight lipgloss.Color
+	match     lipgloss.Color
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
-//	 @Brief			baseBorderStyle creates a style with rounded borders.
+//	@Brief			themeSpec bundles everything needed to build a Theme.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type themeSpec struct {
+	palette themePalette
+	sizes   themeSizes
+	border  lipgloss.Border
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			buildTheme assembles a Theme from its specification.
+//
+//		@Param			spec	themeSpec	Palette, sizes and border shape
+//
+//		@Return			Theme	Theme configuration
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func buildTheme(spec themeSpec) Theme {
+	p, s, border := spec.palette, spec.sizes, spec.border
+
+	return Theme{
+		BorderColor:      p.border,
+		SecondaryColor:   p.secondary,
+		AccentColor:      p.accent,
+		HighlightColor:   p.highlight,
+		MatchColor:       p.match,
+		Border:           border,
+		LeftPanelWidth:   s.leftPanel,
+		RightPanelWidth:  s.rightPanel,
+		ContainerHeight:  s.containerHeight,
+		OuterStyle:       outerStyle(p, s, border),
+		HeaderStyle:      headerStyle(p, border),
+		FooterStyle:      footerStyle(p, border),
+		DimmedBackground: dimStyle(p),
+		ListStyle:        listStyle(s),
+		PreviewStyle:     previewStyle(s),
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			baseBorderStyle creates a style with the theme's border shape.
 //
 //		@Param			border	lipgloss.Color	Border color
+//		@Param			shape	lipgloss.Border	Border shape
 //
 //		@Return			lipgloss.Style	Styled border
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func baseBorderStyle(border lipgloss.Color) lipgloss.Style {
+func baseBorderStyle(border lipgloss.Color, shape lipgloss.Border) lipgloss.Style {
 	return lipgloss.NewStyle().
-		BorderStyle(lipgloss.RoundedBorder()).
+		BorderStyle(shape).
 		BorderForeground(border)
 }
 
@@ -49,15 +93,15 @@ func baseBorderStyle(border lipgloss.Color) lipgloss.Style {
 //
 //	 @Brief			outerStyle creates the style for the outer container.
 //
-//		@Param			p	themePalette	Color palette
-//		@Param			s	themeSizes		Size dimensions
+//		@Param			p		themePalette	Color palette
+//		@Param			s		themeSizes		Size dimensions
+//		@Param			shape	lipgloss.Border	Border shape
 //
 //		@Return			lipgloss.Style	Outer container style
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func outerStyle(p themePalette, s themeSizes) lipgloss.Style {
-	return baseBorderStyle(p.border).
-		BorderStyle(lipgloss.RoundedBorder()).
+func outerStyle(p themePalette, s themeSizes, shape lipgloss.Border) lipgloss.Style {
+	return baseBorderStyle(p.border, shape).
 		Height(s.containerHeight).
 		Padding(s.paddingY, s.paddingX).
 		Margin(s.marginY, s.marginX)
@@ -67,13 +111,14 @@ func outerStyle(p themePalette, s themeSizes) lipgloss.Style {
 //
 //	 @Brief			headerStyle creates the style for the header.
 //
-//		@Param			p	themePalette	Color palette
+//		@Param			p		themePalette	Color palette
+//		@Param			shape	lipgloss.Border	Border shape
 //
 //		@Return			lipgloss.Style	Header style
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func headerStyle(p themePalette) lipgloss.Style {
-	return baseBorderStyle(p.border).
+func headerStyle(p themePalette, shape lipgloss.Border) lipgloss.Style {
+	return baseBorderStyle(p.border, shape).
 		BorderBottom(true).
 		Padding(0, 1).
 		MarginBottom(1)
@@ -83,13 +128,14 @@ func headerStyle(p themePalette) lipgloss.Style {
 //
 //	 @Brief			footerStyle creates the style for the footer.
 //
-//		@Param			p	themePalette	Color palette
+//		@Param			p		themePalette	Color palette
+//		@Param			shape	lipgloss.Border	Border shape
 //
 //		@Return			lipgloss.Style	Footer style
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func footerStyle(p themePalette) lipgloss.Style {
-	return baseBorderStyle(p.border).
+func footerStyle(p themePalette, shape lipgloss.Border) lipgloss.Style {
+	return baseBorderStyle(p.border, shape).
 		BorderTop(true).
 		Foreground(p.secondary).
 		Padding(0, 1).
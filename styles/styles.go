@@ -1,8 +1,13 @@
 package styles
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"strings"
 
-import "strings"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jkeresman01/tsm/config"
+	"github.com/jkeresman01/tsm/utils"
+)
 
 const (
 	LeftPanelWidth  = 45
@@ -21,6 +26,9 @@ type Theme struct {
 	SecondaryColor lipgloss.Color
 	AccentColor    lipgloss.Color
 	HighlightColor lipgloss.Color
+	MatchColor     lipgloss.Color
+
+	Border lipgloss.Border
 
 	LeftPanelWidth  int
 	RightPanelWidth int
@@ -42,14 +50,17 @@ var CurrentTheme Theme
 //
 //	 @Brief			InitTheme initializes the theme based on the provided mode.
 //
-//		@Description	Loads "dark" or "light" theme and refreshes help styles
+//		@Description	Resolves user-defined, bundled or "auto" themes
+//		@Description	Refreshes help styles and the search match highlight color
 //
-//		@Param			mode	string	Theme mode ("dark" or "light")
+//		@Param			mode	string					Theme name (e.g. "dark", "light", "nord", "auto")
+//		@Param			custom	map[string]config.ThemeConfig	User-defined themes keyed by name
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func InitTheme(mode string) {
-	CurrentTheme = pickTheme(normalizeMode(mode))
+func InitTheme(mode string, custom map[string]config.ThemeConfig) {
+	CurrentTheme = pickTheme(normalizeMode(mode), custom)
 	RefreshHelpStyles()
+	utils.SetMatchColor(CurrentTheme.MatchColor)
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -75,18 +86,34 @@ func normalizeMode(mode string) string {
 //
 //	 @Brief			pickTheme selects the appropriate theme based on mode.
 //
-//		@Param			mode	string	Theme mode ("dark" or "light")
+//		@Description	User-defined themes take precedence over bundled ones of the same name
+//
+//		@Param			mode	string					Normalized theme name
+//		@Param			custom	map[string]config.ThemeConfig	User-defined themes keyed by name
 //
 //		@Return			Theme	Selected theme (defaults to dark)
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func pickTheme(mode string) Theme {
-	switch mode {
-	case "light":
-		return LightTheme()
-	case "dark":
-		return DarkTheme()
-	default:
-		return DarkTheme()
+func pickTheme(mode string, custom map[string]config.ThemeConfig) Theme {
+	if mode == "auto" {
+		mode = autoMode()
+	}
+	if tc, ok := custom[mode]; ok {
+		return buildTheme(customSpec(tc))
+	}
+	return buildTheme(bundledSpec(mode))
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			autoMode picks light or dark from the terminal background.
+//
+//		@Return			string	"dark" or "light"
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func autoMode() string {
+	if lipgloss.HasDarkBackground() {
+		return "dark"
 	}
+	return "light"
 }
@@ -0,0 +1,215 @@
+package tmux
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"syscall"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			AttachOptions changes how a session that may be attached elsewhere is joined.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type AttachOptions struct {
+	DetachOthers bool // Detach the session's other clients (attach-session -d)
+	ReadOnly     bool // Attach without accepting input (attach-session -r)
+	Grouped      bool // Attach a new session grouped with the target, see GroupedView
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			destroyView makes a grouped view disappear when its client detaches.
+//
+//		@Description	Must run after attaching, an unattached view would be destroyed at once
+//
+//		@Param			name	string	Grouped view session
+//
+//		@Return			[]string	tmux command
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func destroyView(name string) []string {
+	return []string{"set-option", "-t", name, "destroy-unattached", "on"}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			pendingAttach is an attach AttachSessionWith left for ExecAttach.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type pendingAttach struct {
+	name string
+	opts AttachOptions
+}
+
+// ErrReadOnlySwitch is returned for a read-only attach from inside a client of the server.
+var ErrReadOnlySwitch = errors.New("read-only needs a new client, run 'tsm switch -r' outside tmux")
+
+var (
+	// deferAttach makes AttachSessionWith leave attaching outside tmux to the caller.
+	deferAttach bool
+
+	// pending is the attach requested while deferring.
+	pending *pendingAttach
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			AttachSessionWith attaches to a session with the given options.
+//
+//		@Description	A grouped attach first creates the grouped view session and marks it
+//		@Description	for destruction once attached. Inside a
+//		@Description	client of the selected server the client is switched; otherwise tsm is
+//		@Description	replaced by 'tmux attach-session', or the attach is deferred.
+//		@Description	Read-only is refused inside tmux: it would be a flag of the user's own
+//		@Description	client and stay set for every session switched to afterwards
+//
+//		@Param			name	string			Session name to attach
+//		@Param			opts	AttachOptions	Attach options
+//
+//		@Return			error	Error if a tmux command fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func AttachSessionWith(name string, opts AttachOptions) error {
+	inside := insideServer()
+	if inside && opts.ReadOnly {
+		return ErrReadOnlySwitch
+	}
+	if opts.Grouped {
+		view, err := GroupedView(name)
+		if err != nil {
+			return err
+		}
+		name = view
+	}
+	if inside {
+		return switchClient(name, opts)
+	}
+	if deferAttach {
+		pending = &pendingAttach{name: name, opts: opts}
+		return nil
+	}
+	return ExecAttach(name, opts)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			switchClient switches the calling client to a session.
+//
+//		@Description	switch-client has no -d, so the session's other terminal clients are
+//		@Description	detached one by one after switching. With Grouped, name is a view that is destroyed when detached
+//
+//		@Param			name	string			Session name
+//		@Param			opts	AttachOptions	Attach options
+//
+//		@Return			error	Error if a tmux command fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func switchClient(name string, opts AttachOptions) error {
+	b := NewBatch().Add("switch-client", "-t", name)
+	if opts.Grouped {
+		b.Add(destroyView(name)...)
+	}
+	if _, err := b.Run(); err != nil {
+		return err
+	}
+	if opts.DetachOthers {
+		return detachOthers(name, displayMessage("#{client_name}"))
+	}
+	return nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			GroupedView creates a session grouped with a target for a separate view.
+//
+//		@Description	Grouped sessions share windows but each has its own current window.
+//		@Description	The view is named "<target>-<n>". It is created detached, attaching
+//		@Description	with AttachOptions.Grouped makes it disappear when its client detaches
+//
+//		@Param			target	string	Session to view
+//
+//		@Return			string	Name of the new session
+//		@Return			error	Error if tmux command fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func GroupedView(target string) (string, error) {
+	sessions, err := ListSessions()
+	if err != nil {
+		return "", err
+	}
+	name := target
+	for n := 2; slices.Contains(sessions, name); n++ {
+		name = fmt.Sprintf("%s-%d", target, n)
+	}
+	if err := CreateGroupedSession(name, target); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			DeferAttach stops AttachSession from attaching outside tmux right away.
+//
+//		@Description	Used while the TUI owns the terminal: the session is remembered and
+//		@Description	attached with ExecAttach once the TUI has restored the terminal
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func DeferAttach() {
+	deferAttach = true
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			PendingAttach returns the attach to perform after the TUI exits.
+//
+//		@Return			string			Session name
+//		@Return			AttachOptions	Options of the attach
+//		@Return			bool			False if no attach was requested
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func PendingAttach() (string, AttachOptions, bool) {
+	if pending == nil {
+		return "", AttachOptions{}, false
+	}
+	return pending.name, pending.opts, true
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ExecAttach replaces the tsm process with 'tmux attach-session'.
+//
+//		@Description	$TMUX is removed so attaching from inside another server works.
+//		@Description	DetachOthers detaches the terminal clients first rather than passing -d,
+//		@Description	which would also detach the control-mode clients of tsm watchers
+//
+//		@Param			name	string			Session name to attach
+//		@Param			opts	AttachOptions	Detach others, -r flag, Grouped if name is a grouped view
+//
+//		@Return			error	Error if tmux cannot be executed, never returns otherwise
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func ExecAttach(name string, opts AttachOptions) error {
+	path, err := exec.LookPath("tmux")
+	if err != nil {
+		return ErrNotInstalled
+	}
+	argv := append([]string{"tmux"}, current.Flags()...)
+	argv = append(argv, "attach-session", "-t", name)
+	if opts.DetachOthers {
+		if err := detachOthers(name, ""); err != nil {
+			return err
+		}
+	}
+	if opts.ReadOnly {
+		argv = append(argv, "-r")
+	}
+	if opts.Grouped {
+		argv = append(append(argv, ";"), destroyView(name)...)
+	}
+	return syscall.Exec(path, argv, withoutTMUX(os.Environ()))
+}
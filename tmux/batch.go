@@ -0,0 +1,126 @@
+package tmux
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// batchSeparator is printed after every command of a batch to split the output.
+const batchSeparator = "tsm:batch:separator"
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Batch collects tmux commands to run in a single invocation.
+//
+//		@Description	Runs 'tmux cmd1 \; cmd2 ...' so many queries cost one process spawn.
+//		@Description	Like tmux itself, the batch stops at the first failing command
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Batch struct {
+	cmds [][]string
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			NewBatch creates an empty batch.
+//
+//		@Description	The batch runs against the server selected when Run is called
+//
+//		@Return			*Batch	Empty batch
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func NewBatch() *Batch {
+	return &Batch{}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Add appends a command to the batch.
+//
+//		@Param			args	...string	tmux command and arguments, e.g. "list-windows", "-t", "a"
+//
+//		@Return			*Batch	The batch, for chaining
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (b *Batch) Add(args ...string) *Batch {
+	b.cmds = append(b.cmds, args)
+	return b
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Len returns the number of commands in the batch.
+//
+//		@Return			int		Command count
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (b *Batch) Len() int {
+	return len(b.cmds)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Run executes every command of the batch in one tmux invocation.
+//
+//		@Description	Outputs are returned in command order without the trailing newline.
+//		@Description	If a command fails, the outputs of the commands before it are returned
+//		@Description	together with the error, so len(outputs) is the index of the failed one
+//
+//		@Return			[]string	Output of each command that succeeded
+//		@Return			error		Error of the failing command
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (b *Batch) Run() ([]string, error) {
+	if len(b.cmds) == 0 {
+		return nil, nil
+	}
+	var args []string
+	for i, c := range b.cmds {
+		if i > 0 {
+			args = append(args, ";")
+		}
+		args = append(args, c...)
+		args = append(args, ";", "display-message", "-p", batchSeparator)
+	}
+
+	cmd := command(args...)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	outputs := splitBatchOutput(out.String())
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = errors.New(msg)
+		}
+		return outputs, err
+	}
+	return outputs, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			splitBatchOutput splits the output of a batch at the separators.
+//
+//		@Description	Output after the last separator belongs to a failed command and is dropped
+//
+//		@Param			out		string	Combined stdout of the batch
+//
+//		@Return			[]string	Output per completed command
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func splitBatchOutput(out string) []string {
+	var outputs []string
+	var current []string
+	for _, line := range strings.Split(out, "\n") {
+		if line == batchSeparator {
+			outputs = append(outputs, strings.Join(current, "\n"))
+			current = nil
+			continue
+		}
+		current = append(current, line)
+	}
+	return outputs
+}
@@ -0,0 +1,202 @@
+package tmux
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Event is a notification sent by tmux to a control-mode client.
+//
+//		@Description	Name is the notification without its leading '%', e.g. "session-renamed"
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Event struct {
+	Name string
+	Args []string
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			watchedEvents lists the notifications that change what tsm displays.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+var watchedEvents = map[string]bool{
+	"sessions-changed":       true,
+	"session-changed":        true,
+	"session-renamed":        true,
+	"session-window-changed": true,
+	"window-add":             true,
+	"window-close":           true,
+	"window-renamed":         true,
+	"unlinked-window-add":    true,
+	"unlinked-window-close":  true,
+	"client-session-changed": true,
+	"client-detached":        true,
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			Watcher is a control-mode client reporting session and window changes.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Watcher struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	events chan Event
+	done   chan struct{} // Closed by Close to stop pending deliveries
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Watch attaches a control-mode client to the selected server.
+//
+//		@Description	The client attaches read-only to the first session, ignores its size
+//		@Description	and drops pane output, so it does not disturb other clients.
+//		@Description	A control client needs a session to attach to, so Watch fails when
+//		@Description	the server has none.
+//		@Description	Side effects: the first session counts as attached in
+//		@Description	#{session_attached} and list-clients while tsm runs, and the watcher's
+//		@Description	attach updates its #{session_last_attached}. Filter on
+//		@Description	#{client_control_mode} or #{client_flags} to leave the watcher out.
+//		@Description	Killing that session ends the watcher; the TUI then starts a new one
+//
+//		@Return			*Watcher	Running watcher
+//		@Return			error		ErrUnsupported before tmux 3.2, or an error if there is no
+//		@Return						session or tmux cannot be started
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Watch() (*Watcher, error) {
+	if err := require(Supports().ClientFlags, "control mode", "3.2"); err != nil {
+		return nil, err
+	}
+	sessions, err := ListSessions()
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, errors.New("no session to attach to")
+	}
+
+	cmd := command("-C", "attach-session", "-t", sessions[0], "-f", "read-only,ignore-size,no-output")
+	cmd.Env = withoutTMUX(os.Environ())
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{cmd: cmd, stdin: stdin, events: make(chan Event, 16), done: make(chan struct{})}
+	go w.read(stdout)
+	return w, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Events returns the channel notifications are delivered on.
+//
+//		@Description	The channel is closed when the control client exits
+//
+//		@Return			<-chan Event	Notification channel
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (w *Watcher) Events() <-chan Event {
+	return w.events
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Close detaches the control client.
+//
+//		@Description	Closing stdin makes tmux end the control session, the process is
+//		@Description	reaped by read once its output ends and Events is then closed
+//
+//		@Return			error	Error if stdin cannot be closed
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (w *Watcher) Close() error {
+	close(w.done)
+	return w.stdin.Close()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			read parses control-mode output until the client exits.
+//
+//		@Description	Whatever ends the loop, the rest of the output is drained before the
+//		@Description	process is waited for, as os/exec requires, and Events is closed last
+//
+//		@Param			r		io.Reader	stdout of the control client
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (w *Watcher) read(r io.Reader) {
+	defer func() {
+		io.Copy(io.Discard, r)
+		w.cmd.Wait()
+		close(w.events)
+	}()
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	inBlock := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "%begin "):
+			inBlock = true
+		case strings.HasPrefix(line, "%end "), strings.HasPrefix(line, "%error "):
+			inBlock = false
+		case strings.HasPrefix(line, "%exit"):
+			return
+		case !inBlock:
+			if ev, ok := ParseEvent(line); ok && watchedEvents[ev.Name] {
+				select {
+				case w.events <- ev:
+				case <-w.done:
+					return
+				}
+			}
+		}
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ParseEvent parses a control-mode notification line.
+//
+//		@Description	Arguments are split on spaces, the last one keeps the rest of the
+//		@Description	line for notifications carrying a name (e.g. "%session-renamed $1 a b")
+//
+//		@Param			line	string	Line read from a control client
+//
+//		@Return			Event	Parsed notification
+//		@Return			bool	False if the line is not a notification
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func ParseEvent(line string) (Event, bool) {
+	rest, ok := strings.CutPrefix(line, "%")
+	if !ok || rest == "" {
+		return Event{}, false
+	}
+	name, args, _ := strings.Cut(rest, " ")
+	ev := Event{Name: name}
+	switch name {
+	case "session-renamed", "window-renamed":
+		if id, value, ok := strings.Cut(args, " "); ok {
+			ev.Args = []string{id, value}
+		}
+	default:
+		ev.Args = strings.Fields(args)
+	}
+	return ev, true
+}
@@ -0,0 +1,31 @@
+package tmux
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		line string
+		want Event
+		ok   bool
+	}{
+		{"%sessions-changed", Event{Name: "sessions-changed"}, true},
+		{"%window-add @3", Event{Name: "window-add", Args: []string{"@3"}}, true},
+		{"%session-changed $1 work", Event{Name: "session-changed", Args: []string{"$1", "work"}}, true},
+		{"%session-renamed $1 my project", Event{Name: "session-renamed", Args: []string{"$1", "my project"}}, true},
+		{"%window-renamed @2 vim  main.go", Event{Name: "window-renamed", Args: []string{"@2", "vim  main.go"}}, true},
+		{"%session-renamed $1", Event{Name: "session-renamed"}, true},
+		{"%client-detached /dev/pts/3", Event{Name: "client-detached", Args: []string{"/dev/pts/3"}}, true},
+		{"%", Event{}, false},
+		{"", Event{}, false},
+		{"plain output", Event{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseEvent(tt.line)
+		if ok != tt.ok || got.Name != tt.want.Name || !slices.Equal(got.Args, tt.want.Args) {
+			t.Errorf("ParseEvent(%q) = %+v, %v, want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package tmux
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+var (
+	// ErrNoServer is returned when no tmux server listens on the selected socket.
+	ErrNoServer = errors.New("no tmux server running")
+
+	// ErrNotInstalled is returned when the tmux executable cannot be found.
+	ErrNotInstalled = errors.New("tmux is not installed")
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			classifyError maps a failed tmux invocation to a package error.
+//
+//		@Description	tmux reports a missing server as "no server running on <socket>" or
+//		@Description	"error connecting to <socket> (No such file or directory)"
+//
+//		@Param			err		error	Error from running the command
+//		@Param			stderr	string	Standard error of the command
+//
+//		@Return			error	ErrNotInstalled, ErrNoServer or err with tmux's message
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func classifyError(err error, stderr string) error {
+	if errors.Is(err, exec.ErrNotFound) {
+		return ErrNotInstalled
+	}
+	msg := strings.TrimSpace(stderr)
+	if strings.HasPrefix(msg, "no server running") ||
+		(strings.HasPrefix(msg, "error connecting to") && strings.Contains(msg, "No such file or directory")) {
+		return ErrNoServer
+	}
+	if msg == "" {
+		return err
+	}
+	return errors.New(msg)
+}
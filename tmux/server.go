@@ -0,0 +1,252 @@
+package tmux
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Server selects the tmux server to talk to.
+//
+//		@Description	SocketPath (-S) wins over SocketName (-L), both empty is the default server
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Server struct {
+	SocketName string // Socket name passed with -L
+	SocketPath string // Socket path passed with -S
+}
+
+// current is the server used by every function of this package.
+var current Server
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SetServer selects the server used by all following tmux invocations.
+//
+//		@Param			s	Server	Server to use
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func SetServer(s Server) {
+	current = s
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			CurrentServer returns the server tmux invocations go to.
+//
+//		@Return			Server	Selected server
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func CurrentServer() Server {
+	return current
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Label returns a short name for the server.
+//
+//		@Return			string	Socket name, socket file name or "default"
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (s Server) Label() string {
+	switch {
+	case s.SocketPath != "":
+		return filepath.Base(s.SocketPath)
+	case s.SocketName != "":
+		return s.SocketName
+	default:
+		return "default"
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Socket returns the socket file of the server.
+//
+//		@Description	Mirrors tmux: $TMUX_TMPDIR (or /tmp), tmux-<uid>, then the socket name
+//
+//		@Return			string	Absolute socket path
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (s Server) Socket() string {
+	if s.SocketPath != "" {
+		return s.SocketPath
+	}
+	name := s.SocketName
+	if name == "" {
+		name = "default"
+	}
+	return filepath.Join(SocketDir(), name)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Flags returns the server selection flags for a tmux command line.
+//
+//		@Return			[]string	"-S path", "-L name" or nothing
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (s Server) Flags() []string {
+	switch {
+	case s.SocketPath != "":
+		return []string{"-S", s.SocketPath}
+	case s.SocketName != "":
+		return []string{"-L", s.SocketName}
+	default:
+		return nil
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			command builds a tmux command for this server.
+//
+//		@Param			args	...string	tmux command and arguments
+//
+//		@Return			*exec.Cmd	Command ready to run
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (s Server) command(args ...string) *exec.Cmd {
+	return exec.Command("tmux", append(s.Flags(), args...)...)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			command builds a tmux command for the selected server.
+//
+//		@Param			args	...string	tmux command and arguments
+//
+//		@Return			*exec.Cmd	Command ready to run
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func command(args ...string) *exec.Cmd {
+	return current.command(args...)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			insideServer reports whether tsm runs inside a client of the selected server.
+//
+//		@Description	Without -L or -S the server is the one in $TMUX, as for tmux itself.
+//		@Description	Otherwise the sockets are compared after resolving symlinks, since
+//		@Description	tmux stores the real path (e.g. /private/tmp on macOS)
+//
+//		@Return			bool	True if switch-client can be used
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func insideServer() bool {
+	env := os.Getenv("TMUX")
+	if env == "" {
+		return false
+	}
+	if current == (Server{}) {
+		return true
+	}
+	socket, _, _ := strings.Cut(env, ",")
+	return realPath(socket) == realPath(current.Socket())
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			realPath resolves symlinks in a path.
+//
+//		@Param			path	string	Path to resolve
+//
+//		@Return			string	Resolved path, or the cleaned path if it cannot be resolved
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func realPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SocketDir returns the directory holding the sockets of named servers.
+//
+//		@Return			string	$TMUX_TMPDIR/tmux-<uid>, /tmp/tmux-<uid> by default
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func SocketDir() string {
+	base := os.Getenv("TMUX_TMPDIR")
+	if base == "" {
+		base = "/tmp"
+	}
+	return filepath.Join(base, fmt.Sprintf("tmux-%d", os.Getuid()))
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			DiscoverServers lists the servers with a socket in SocketDir.
+//
+//		@Description	The selected server is included even when its socket lives elsewhere
+//
+//		@Return			[]Server	Discovered servers, the selected one first
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func DiscoverServers() []Server {
+	servers := []Server{current}
+	entries, _ := os.ReadDir(SocketDir())
+	for _, e := range entries {
+		if e.Type()&os.ModeSocket == 0 {
+			continue
+		}
+		s := Server{SocketName: e.Name()}
+		if s.Socket() != current.Socket() {
+			servers = append(servers, s)
+		}
+	}
+	return servers
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ListServerSessions retrieves the sessions of a specific server.
+//
+//		@Param			s	Server	Server to query
+//
+//		@Return			[]string	Session names, nil for a server without sessions
+//		@Return			error		Error if the server is not running
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func ListServerSessions(s Server) ([]string, error) {
+	out, err := s.command("list-sessions", "-F", "#S").Output()
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(string(out)) == "" {
+		return nil, nil
+	}
+	return strings.Split(strings.TrimSpace(string(out)), "\n"), nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Popup runs a command in a popup over the current tmux client.
+//
+//		@Description	Executes 'tmux display-popup -E', which needs tmux 3.2
+//
+//		@Param			argv	...string	Program and arguments
+//
+//		@Return			error	ErrUnsupported on older tmux or the popup's error
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Popup(argv ...string) error {
+	if err := require(Supports().Popup, "display-popup", "3.2"); err != nil {
+		return err
+	}
+	if !insideServer() {
+		return fmt.Errorf("display-popup must be run inside a client of server %s", current.Label())
+	}
+	quoted := make([]string, len(argv))
+	for i, a := range argv {
+		quoted[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+	}
+	return command("display-popup", "-E", "-w", "80%", "-h", "80%", strings.Join(quoted, " ")).Run()
+}
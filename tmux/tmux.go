@@ -2,9 +2,10 @@ package tmux
 
 import (
 	"bytes"
-	"os"
-	"os/exec"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -14,16 +15,20 @@ import (
 //		@Description	Executes 'tmux list-sessions' and parses session names
 //
 //		@Return			[]string	List of session names
-//		@Return			error		Error if tmux command fails
+//		@Return			error		ErrNoServer, ErrNotInstalled or the tmux error
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func ListSessions() ([]string, error) {
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#S")
-	var out bytes.Buffer
+	cmd := command("list-sessions", "-F", "#S")
+	var out, stderr bytes.Buffer
 	cmd.Stdout = &out
+	cmd.Stderr = &stderr
 	err := cmd.Run()
 	if err != nil {
-		return nil, err
+		return nil, classifyError(err, stderr.String())
+	}
+	if strings.TrimSpace(out.String()) == "" {
+		return nil, nil
 	}
 	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
 	return lines, nil
@@ -42,7 +47,7 @@ func ListSessions() ([]string, error) {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func GetPreview(session string) (string, error) {
-	cmd := exec.Command("tmux", "capture-pane", "-t", session, "-p", "-S", "-10")
+	cmd := command("capture-pane", "-t", session, "-p", "-S", "-10")
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	err := cmd.Run()
@@ -65,7 +70,7 @@ func GetPreview(session string) (string, error) {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func RenameSession(oldName, newName string) error {
-	cmd := exec.Command("tmux", "rename-session", "-t", oldName, newName)
+	cmd := command("rename-session", "-t", oldName, newName)
 	return cmd.Run()
 }
 
@@ -73,7 +78,9 @@ func RenameSession(oldName, newName string) error {
 //
 //	 @Brief			AttachSession attaches to or switches to a tmux session.
 //
-//		@Description	Uses 'switch-client' if already in tmux, otherwise 'attach-session'
+//		@Description	Uses 'switch-client' if already in a client of the selected server.
+//		@Description	Otherwise tsm is replaced by 'tmux attach-session' (nested when inside
+//		@Description	another server), or the attach is left for after the TUI, see DeferAttach
 //
 //		@Param			name	string	Session name to attach
 //
@@ -81,19 +88,7 @@ func RenameSession(oldName, newName string) error {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func AttachSession(name string) error {
-	if os.Getenv("TMUX") != "" {
-		cmd := exec.Command("tmux", "switch-client", "-t", name)
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		return cmd.Run()
-	}
-
-	cmd := exec.Command("tmux", "attach-session", "-t", name)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	return AttachSessionWith(name, AttachOptions{})
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -109,6 +104,532 @@ func AttachSession(name string) error {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func CreateSession(name, path string) error {
-	cmd := exec.Command("tmux", "new-session", "-d", "-s", name, "-c", path)
+	return CreateSessionWith(name, path, SessionOptions{})
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			SessionOptions holds optional settings for a new session.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type SessionOptions struct {
+	Env     []string // Session environment as KEY=VALUE
+	Command string   // Shell command run instead of the default shell, empty for the shell
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			CreateSessionWith creates a new detached tmux session with options.
+//
+//		@Description	The environment is passed with 'new-session -e'. Before tmux 3.2 it is
+//		@Description	set with 'set-environment' instead, which only reaches later windows.
+//		@Description	tmux before 1.9 has no -c so the directory is inherited instead.
+//		@Description	A command replaces the shell of the first window, which closes with it
+//
+//		@Param			name	string			Session name
+//		@Param			path	string			Working directory for the session
+//		@Param			opts	SessionOptions	Environment and startup command
+//
+//		@Return			error	Error if tmux command fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func CreateSessionWith(name, path string, opts SessionOptions) error {
+	caps := Supports()
+	args := []string{"new-session", "-d", "-s", name}
+	if caps.StartDirectory {
+		args = append(args, "-c", path)
+	}
+	var later []string
+	for _, kv := range opts.Env {
+		if caps.SessionEnvironment {
+			args = append(args, "-e", kv)
+			continue
+		}
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			later = append(later, ";", "set-environment", "-t", name, k, v)
+		}
+	}
+	if opts.Command != "" {
+		args = append(args, opts.Command)
+	}
+	cmd := command(append(args, later...)...)
+	if !caps.StartDirectory {
+		cmd.Dir = path
+	}
+	return cmd.Run()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			Window describes a tmux window of a session.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Window struct {
+	Index string // Window index within its session
+	Name  string // Window name
+	Path  string // Working directory of the active pane
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			KillSession destroys a tmux session.
+//
+//		@Description	Executes 'tmux kill-session'
+//
+//		@Param			name	string	Session name
+//
+//		@Return			error	Error if tmux command fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func KillSession(name string) error {
+	cmd := command("kill-session", "-t", name)
+	return cmd.Run()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			DetachClients detaches every client attached to a session.
+//
+//		@Description	Control-mode clients, such as the watcher of a running tsm, stay attached
+//
+//		@Param			name	string	Session name
+//
+//		@Return			error	Error if tmux command fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func DetachClients(name string) error {
+	return detachOthers(name, "")
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			detachOthers detaches the terminal clients of a session except one.
+//
+//		@Description	Used instead of 'detach-client -s' and 'attach-session -d', which
+//		@Description	would also detach the control-mode clients of tsm watchers
+//
+//		@Param			name	string	Session name
+//		@Param			self	string	Client to keep, empty to detach all of them
+//
+//		@Return			error	Error if tmux command fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func detachOthers(name, self string) error {
+	out, err := command("list-clients", "-t", name, "-F", "#{client_control_mode} #{client_name}").Output()
+	if err != nil {
+		return err
+	}
+	b := NewBatch()
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		control, client, ok := strings.Cut(line, " ")
+		if ok && control != "1" && client != self {
+			b.Add("detach-client", "-t", client)
+		}
+	}
+	_, err = b.Run()
+	return err
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SessionPath returns the working directory of a session.
+//
+//		@Description	Executes 'tmux display-message' with #{session_path}
+//
+//		@Param			name	string	Session name
+//
+//		@Return			string	Session working directory
+//		@Return			error	Error if tmux command fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func SessionPath(name string) (string, error) {
+	cmd := command("display-message", "-p", "-t", name, "#{session_path}")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	err := cmd.Run()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ListWindows retrieves the windows of a session.
+//
+//		@Description	Executes 'tmux list-windows' and parses index, name and pane path
+//
+//		@Param			session	string	Session name
+//
+//		@Return			[]Window	Windows in index order
+//		@Return			error		Error if tmux command fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func ListWindows(session string) ([]Window, error) {
+	cmd := command("list-windows", "-t", session, "-F", windowFormat)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	err := cmd.Run()
+	if err != nil {
+		return nil, err
+	}
+	return parseWindows(out.String()), nil
+}
+
+// windowFormat is the list-windows format parsed by parseWindows.
+const windowFormat = "#{window_index}\t#{window_name}\t#{pane_current_path}"
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			parseWindows parses list-windows output printed with windowFormat.
+//
+//		@Param			out		string	Command output, one window per line
+//
+//		@Return			[]Window	Parsed windows, malformed lines are skipped
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func parseWindows(out string) []Window {
+	var windows []Window
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		windows = append(windows, Window{Index: fields[0], Name: fields[1], Path: fields[2]})
+	}
+	return windows
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			SessionLayout is the directory and window list of a session.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type SessionLayout struct {
+	Path    string   // Working directory of the session
+	Windows []Window // Windows in index order
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ListSessionLayouts retrieves the layout of several sessions at once.
+//
+//		@Description	Queries all sessions in one Batch. A session that cannot be read ends
+//		@Description	the batch, so it is skipped and the rest are queried again
+//
+//		@Param			names	[]string	Session names
+//
+//		@Return			map[string]SessionLayout	Layouts of the sessions that exist
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func ListSessionLayouts(names []string) map[string]SessionLayout {
+	layouts := make(map[string]SessionLayout, len(names))
+	for len(names) > 0 {
+		b := NewBatch()
+		for _, name := range names {
+			b.Add("display-message", "-p", "-t", name, "#{session_path}")
+			b.Add("list-windows", "-t", name, "-F", windowFormat)
+		}
+		outputs, err := b.Run()
+		done := len(outputs) / 2
+		for i := 0; i < done; i++ {
+			layouts[names[i]] = SessionLayout{
+				Path:    strings.TrimSpace(outputs[2*i]),
+				Windows: parseWindows(outputs[2*i+1]),
+			}
+		}
+		if err == nil || done >= len(names) {
+			break
+		}
+		names = names[done+1:]
+	}
+	return layouts
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			CreateGroupedSession creates a detached session grouped with a target.
+//
+//		@Description	Executes 'tmux new-session -t', sessions in a group share their windows
+//
+//		@Param			name	string	New session name
+//		@Param			target	string	Session whose group to join
+//
+//		@Return			error	Error if tmux command fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func CreateGroupedSession(name, target string) error {
+	cmd := command("new-session", "-d", "-s", name, "-t", target)
 	return cmd.Run()
 }
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			JoinGroup moves a session into the session group of a target.
+//
+//		@Description	tmux cannot regroup a live session, so its windows are moved to
+//		@Description	the target and the session is recreated inside the target's group.
+//		@Description	The session ends when its last window leaves, detaching its clients.
+//		@Description	Stops at the first failure; the error names the windows already moved
+//
+//		@Param			name	string	Session to move
+//		@Param			target	string	Session whose group to join
+//
+//		@Return			error	Error if the target is missing or a tmux command fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func JoinGroup(name, target string) error {
+	if name == target {
+		return nil
+	}
+	if !HasSession(target) {
+		return fmt.Errorf("session %q not found", target)
+	}
+	windows, err := ListWindows(name)
+	if err != nil {
+		return err
+	}
+	var moved []string
+	for _, w := range windows {
+		cmd := command("move-window", "-s", name+":"+w.Index, "-t", target+":")
+		if err := cmd.Run(); err != nil {
+			return joinError(moved, target, fmt.Errorf("moving window %s:%s: %w", name, w.Index, err))
+		}
+		moved = append(moved, name+":"+w.Index)
+	}
+	if err := CreateGroupedSession(name, target); err != nil {
+		return joinError(moved, target, fmt.Errorf("recreating %s: %w", name, err))
+	}
+	return nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			joinError adds the windows a failed JoinGroup already moved to its error.
+//
+//		@Param			moved	[]string	Moved windows as "session:index"
+//		@Param			target	string		Session the windows were moved to
+//		@Param			err		error		Failure
+//
+//		@Return			error	err, naming the moved windows if there are any
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func joinError(moved []string, target string, err error) error {
+	if len(moved) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w; windows %s were moved to %s", err, strings.Join(moved, ", "), target)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			SessionInfo describes a session for sorting and decorations.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type SessionInfo struct {
+	Name     string    // Session name
+	Path     string    // Working directory of the session
+	Created  time.Time // Creation time
+	Activity time.Time // Time of the last activity
+	Windows  int       // Number of windows
+	Group    string    // Session group, empty if the session is not grouped
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ListSessionInfo retrieves details of every session.
+//
+//		@Description	Executes a single 'tmux list-sessions' with path, times, window count and group.
+//		@Description	tmux before 2.1 lacks activity and groups: Activity is then the creation
+//		@Description	time and Group is empty
+//
+//		@Return			map[string]SessionInfo	Session name to details
+//		@Return			error					Error if tmux command fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func ListSessionInfo() (map[string]SessionInfo, error) {
+	cmd := command("list-sessions", "-F", sessionInfoFormat(Supports().SessionFormats))
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	err := cmd.Run()
+	if err != nil {
+		return nil, err
+	}
+	infos := make(map[string]SessionInfo)
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if info, ok := parseSessionInfo(line); ok {
+			infos[info.Name] = info
+		}
+	}
+	return infos, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			sessionInfoFormat returns the list-sessions format parsed by parseSessionInfo.
+//
+//		@Param			full	bool	Whether tmux knows #{session_activity} and #{session_group}
+//
+//		@Return			string	Tab separated format, 6 fields when full, 4 otherwise
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func sessionInfoFormat(full bool) string {
+	format := "#S\t#{session_path}\t#{session_created}\t#{session_windows}"
+	if full {
+		format += "\t#{session_activity}\t#{session_group}"
+	}
+	return format
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			parseSessionInfo parses a line printed with sessionInfoFormat.
+//
+//		@Param			line	string	Tab separated fields
+//
+//		@Return			SessionInfo	Parsed details
+//		@Return			bool		False for malformed lines
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func parseSessionInfo(line string) (SessionInfo, bool) {
+	fields := strings.Split(line, "\t")
+	if len(fields) != 4 && len(fields) != 6 {
+		return SessionInfo{}, false
+	}
+	windows, _ := strconv.Atoi(fields[3])
+	info := SessionInfo{
+		Name:     fields[0],
+		Path:     fields[1],
+		Created:  unixTime(fields[2]),
+		Windows:  windows,
+		Activity: unixTime(fields[2]),
+	}
+	if len(fields) == 6 {
+		info.Activity = unixTime(fields[4])
+		info.Group = fields[5]
+	}
+	return info, true
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			unixTime parses a tmux timestamp in seconds.
+//
+//		@Param			s	string	Seconds since the epoch
+//
+//		@Return			time.Time	Parsed time, zero if malformed
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func unixTime(s string) time.Time {
+	secs, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SanitizeSessionName makes a name acceptable as a tmux session name.
+//
+//		@Description	tmux rejects ':' and silently rewrites '.', both become '_'
+//
+//		@Param			name	string	Proposed session name
+//
+//		@Return			string	Sanitized session name
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func SanitizeSessionName(name string) string {
+	return strings.NewReplacer(":", "_", ".", "_").Replace(name)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			CurrentSession returns the session of the calling tmux client.
+//
+//		@Description	Returns an empty string outside a client of the selected server
+//
+//		@Return			string	Current session name
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func CurrentSession() string {
+	if !insideServer() {
+		return ""
+	}
+	return displayMessage("#S")
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			LastSession returns tmux's own previous session of the calling client.
+//
+//		@Description	Uses #{client_last_session}, empty outside a client of the selected server
+//		@Description	or before tmux 2.1
+//
+//		@Return			string	Previous session name
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func LastSession() string {
+	if !insideServer() || !Supports().SessionFormats {
+		return ""
+	}
+	return displayMessage("#{client_last_session}")
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			HasSession reports whether a session exists.
+//
+//		@Description	Executes 'tmux has-session', matching the name exactly where supported
+//
+//		@Param			name	string	Session name
+//
+//		@Return			bool	True if the session exists
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func HasSession(name string) bool {
+	target := name
+	if Supports().ExactTargets {
+		target = "=" + name
+	}
+	cmd := command("has-session", "-t", target)
+	return cmd.Run() == nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			displayMessage expands a format for the calling client.
+//
+//		@Param			format	string	tmux format string
+//
+//		@Return			string	Expanded format, empty on error
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func displayMessage(format string) string {
+	cmd := command("display-message", "-p", format)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out.String())
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			withoutTMUX removes $TMUX so tmux allows attaching from inside another server.
+//
+//		@Param			env		[]string	Environment in os.Environ form
+//
+//		@Return			[]string	Environment without TMUX
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func withoutTMUX(env []string) []string {
+	out := make([]string, 0, len(env))
+	for _, kv := range env {
+		if !strings.HasPrefix(kv, "TMUX=") {
+			out = append(out, kv)
+		}
+	}
+	return out
+}
@@ -0,0 +1,233 @@
+package tmux
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupported is returned when the installed tmux lacks a feature.
+var ErrUnsupported = errors.New("not supported by this tmux version")
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			VersionInfo is a parsed 'tmux -V' version.
+//
+//		@Description	Development builds such as "tmux master" have Dev set and support everything
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type VersionInfo struct {
+	Major  int
+	Minor  int
+	Suffix string // Patch letter, e.g. "a" in 3.3a
+	Dev    bool   // Built from a development branch
+	Raw    string // Version as printed by tmux
+}
+
+// versionPattern matches the numeric part of a tmux version, e.g. "3.3a" or "next-3.4".
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)([a-z]?)`)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ParseVersion parses the output of 'tmux -V'.
+//
+//		@Param			out		string	Output such as "tmux 3.3a" or "tmux next-3.4"
+//
+//		@Return			VersionInfo	Parsed version
+//		@Return			error		Error if out is not a tmux version
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func ParseVersion(out string) (VersionInfo, error) {
+	raw, ok := strings.CutPrefix(strings.TrimSpace(out), "tmux ")
+	if !ok {
+		return VersionInfo{}, fmt.Errorf("unexpected tmux version %q", out)
+	}
+	v := VersionInfo{Raw: raw}
+	m := versionPattern.FindStringSubmatch(raw)
+	if m == nil {
+		v.Dev = true
+		return v, nil
+	}
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	v.Suffix = m[3]
+	v.Dev = strings.HasPrefix(raw, "next-")
+	return v, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			AtLeast reports whether the version is major.minor or newer.
+//
+//		@Param			major	int	Required major version
+//		@Param			minor	int	Required minor version
+//
+//		@Return			bool	True if the version is new enough
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (v VersionInfo) AtLeast(major, minor int) bool {
+	if v.Dev || v.Major != major {
+		return v.Dev || v.Major > major
+	}
+	return v.Minor >= minor
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			String returns the version as printed by tmux.
+//
+//		@Return			string	Version such as "3.3a"
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (v VersionInfo) String() string {
+	return v.Raw
+}
+
+var (
+	versionOnce sync.Once
+	version     VersionInfo
+	versionErr  error
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Version returns the version of the installed tmux.
+//
+//		@Description	Runs 'tmux -V' once and caches the result
+//
+//		@Return			VersionInfo	Installed version
+//		@Return			error		Error if tmux cannot be run
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Version() (VersionInfo, error) {
+	versionOnce.Do(func() {
+		out, err := exec.Command("tmux", "-V").Output()
+		if err != nil {
+			versionErr = err
+			return
+		}
+		version, versionErr = ParseVersion(string(out))
+	})
+	return version, versionErr
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			Capabilities lists the version dependent tmux features tsm uses.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Capabilities struct {
+	StartDirectory     bool // new-session -c (1.9)
+	ExactTargets       bool // "=name" targets matching a session exactly (2.1)
+	Popup              bool // display-popup (3.2)
+	ClientFlags        bool // attach-session -f read-only,ignore-size,no-output (3.2)
+	SessionEnvironment bool // new-session -e (3.2)
+	SessionFormats     bool // #{session_activity}, #{session_group}, #{client_last_session} (2.1)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			feature describes a capability for startup notices.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type feature struct {
+	name         string
+	major, minor int
+	enabled      func(Capabilities) bool
+}
+
+// features lists the capabilities with the tsm behaviour that depends on them.
+var features = []feature{
+	{"session directories", 1, 9, func(c Capabilities) bool { return c.StartDirectory }},
+	{"exact session names", 2, 1, func(c Capabilities) bool { return c.ExactTargets }},
+	{"activity sorting and session groups", 2, 1, func(c Capabilities) bool { return c.SessionFormats }},
+	{"live updates", 3, 2, func(c Capabilities) bool { return c.ClientFlags }},
+	{"tsm popup", 3, 2, func(c Capabilities) bool { return c.Popup }},
+	{"session environment", 3, 2, func(c Capabilities) bool { return c.SessionEnvironment }},
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			CapabilitiesOf returns the features available in a tmux version.
+//
+//		@Param			v	VersionInfo	tmux version
+//
+//		@Return			Capabilities	Available features
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func CapabilitiesOf(v VersionInfo) Capabilities {
+	return Capabilities{
+		StartDirectory:     v.AtLeast(1, 9),
+		ExactTargets:       v.AtLeast(2, 1),
+		Popup:              v.AtLeast(3, 2),
+		ClientFlags:        v.AtLeast(3, 2),
+		SessionEnvironment: v.AtLeast(3, 2),
+		SessionFormats:     v.AtLeast(2, 1),
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Supports returns the features of the installed tmux.
+//
+//		@Description	Everything is assumed available when the version cannot be read,
+//		@Description	so a missing tmux is reported by the commands themselves
+//
+//		@Return			Capabilities	Available features
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Supports() Capabilities {
+	v, err := Version()
+	if err != nil {
+		return CapabilitiesOf(VersionInfo{Dev: true})
+	}
+	return CapabilitiesOf(v)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Notice explains which features the installed tmux is too old for.
+//
+//		@Return			string	Message for the user, empty if everything is supported
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func Notice() string {
+	v, err := Version()
+	if err != nil {
+		return ""
+	}
+	caps := CapabilitiesOf(v)
+	var missing []string
+	for _, f := range features {
+		if !f.enabled(caps) {
+			missing = append(missing, fmt.Sprintf("%s (%d.%d)", f.name, f.major, f.minor))
+		}
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("tmux %s lacks %s", v, strings.Join(missing, ", "))
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			require returns ErrUnsupported with the needed version if a feature is missing.
+//
+//		@Param			ok		bool	Whether the feature is available
+//		@Param			what	string	Feature name for the error
+//		@Param			since	string	First tmux version with the feature
+//
+//		@Return			error	nil if ok
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func require(ok bool, what, since string) error {
+	if ok {
+		return nil
+	}
+	v, _ := Version()
+	return fmt.Errorf("%s needs tmux %s or newer, found %s: %w", what, since, v, ErrUnsupported)
+}
@@ -0,0 +1,98 @@
+package tmux
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		out  string
+		want VersionInfo
+	}{
+		{"tmux 3.3a\n", VersionInfo{Major: 3, Minor: 3, Suffix: "a", Raw: "3.3a"}},
+		{"tmux 2.1", VersionInfo{Major: 2, Minor: 1, Raw: "2.1"}},
+		{"tmux 1.8", VersionInfo{Major: 1, Minor: 8, Raw: "1.8"}},
+		{"tmux next-3.4", VersionInfo{Major: 3, Minor: 4, Dev: true, Raw: "next-3.4"}},
+		{"tmux master", VersionInfo{Dev: true, Raw: "master"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.out)
+		if err != nil {
+			t.Errorf("ParseVersion(%q): %v", tt.out, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseVersion(%q) = %+v, want %+v", tt.out, got, tt.want)
+		}
+	}
+}
+
+func TestParseVersionErrors(t *testing.T) {
+	for _, out := range []string{"", "3.3a", "screen 4.09"} {
+		if v, err := ParseVersion(out); err == nil {
+			t.Errorf("ParseVersion(%q) = %+v, want an error", out, v)
+		}
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	tests := []struct {
+		v            VersionInfo
+		major, minor int
+		want         bool
+	}{
+		{VersionInfo{Major: 3, Minor: 2}, 3, 2, true},
+		{VersionInfo{Major: 3, Minor: 1}, 3, 2, false},
+		{VersionInfo{Major: 2, Minor: 9}, 3, 2, false},
+		{VersionInfo{Major: 4, Minor: 0}, 3, 2, true},
+		{VersionInfo{Major: 2, Minor: 0}, 1, 9, true},
+		{VersionInfo{Dev: true}, 3, 2, true},
+	}
+	for _, tt := range tests {
+		if got := tt.v.AtLeast(tt.major, tt.minor); got != tt.want {
+			t.Errorf("%+v.AtLeast(%d, %d) = %v, want %v", tt.v, tt.major, tt.minor, got, tt.want)
+		}
+	}
+}
+
+func TestCapabilitiesOf(t *testing.T) {
+	old := CapabilitiesOf(VersionInfo{Major: 2, Minor: 0})
+	if old.SessionFormats || old.ExactTargets || !old.StartDirectory || old.ClientFlags {
+		t.Errorf("CapabilitiesOf(2.0) = %+v", old)
+	}
+	formats := CapabilitiesOf(VersionInfo{Major: 2, Minor: 1})
+	if !formats.SessionFormats || !formats.ExactTargets || formats.Popup {
+		t.Errorf("CapabilitiesOf(2.1) = %+v", formats)
+	}
+	all := CapabilitiesOf(VersionInfo{Major: 3, Minor: 2})
+	want := Capabilities{
+		StartDirectory:     true,
+		ExactTargets:       true,
+		Popup:              true,
+		ClientFlags:        true,
+		SessionEnvironment: true,
+		SessionFormats:     true,
+	}
+	if all != want {
+		t.Errorf("CapabilitiesOf(3.2) = %+v", all)
+	}
+}
+
+func TestParseSessionInfo(t *testing.T) {
+	full, ok := parseSessionInfo("api\t/src/api\t100\t3\t200\tgroup")
+	want := SessionInfo{Name: "api", Path: "/src/api", Created: time.Unix(100, 0), Activity: time.Unix(200, 0), Windows: 3, Group: "group"}
+	if !ok || full != want {
+		t.Errorf("parseSessionInfo(full) = %+v, %v, want %+v", full, ok, want)
+	}
+	short, ok := parseSessionInfo("api\t/src/api\t100\t3")
+	want = SessionInfo{Name: "api", Path: "/src/api", Created: time.Unix(100, 0), Activity: time.Unix(100, 0), Windows: 3}
+	if !ok || short != want {
+		t.Errorf("parseSessionInfo(pre 2.1) = %+v, %v, want %+v", short, ok, want)
+	}
+	for _, line := range []string{"", "api\t/src/api", "api\t/src/api\t100\t3\t200"} {
+		if _, ok := parseSessionInfo(line); ok {
+			t.Errorf("parseSessionInfo(%q) accepted a malformed line", line)
+		}
+	}
+}
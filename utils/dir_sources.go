@@ -0,0 +1,345 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			ScoredDir is a directory reported by a source together with its score.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type ScoredDir struct {
+	Path  string
+	Score float64
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			DirSource provides directories in addition to the scanned search paths.
+//
+//		@Description	Implemented for zoxide, autojump, fasd and arbitrary commands
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type DirSource interface {
+	/////////////////////////////////////////////////////////////////////////////////////////////
+	//
+	//  @Brief			Dirs returns the source's directories with their scores.
+	//
+	//	@Return			[]ScoredDir	Directories, higher scores rank first
+	//	@Return			error		Error if the source is unavailable
+	//
+	/////////////////////////////////////////////////////////////////////////////////////////////
+	Dirs() ([]ScoredDir, error)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			WeightedSource pairs a source with the multiplier applied to its scores.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type WeightedSource struct {
+	Name   string // Source type, used in errors
+	Source DirSource
+	Weight float64
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			NewDirSource creates a directory source by type name.
+//
+//		@Param			kind	string	"zoxide", "autojump", "fasd" or "command"
+//		@Param			command	string	Shell command for the "command" source
+//		@Param			path	string	Database file override for zoxide, autojump and fasd
+//
+//		@Return			DirSource	Configured source
+//		@Return			error		Error for unknown types or a missing command
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func NewDirSource(kind, command, path string) (DirSource, error) {
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "zoxide":
+		return zoxideSource{path: ExpandHome(path)}, nil
+	case "autojump":
+		return autojumpSource{path: firstNonEmpty(ExpandHome(path), autojumpDataPath())}, nil
+	case "fasd":
+		return fasdSource{path: firstNonEmpty(ExpandHome(path), fasdDataPath())}, nil
+	case "command":
+		if strings.TrimSpace(command) == "" {
+			return nil, fmt.Errorf("command source requires a command")
+		}
+		return commandSource{command: command}, nil
+	default:
+		return nil, fmt.Errorf("unknown directory source %q", kind)
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			MergeDirSources merges source directories into the scanned directories.
+//
+//		@Description	Scores of the same directory are summed across sources
+//		@Description	Scanned directories score 0, missing directories are dropped
+//		@Description	Result is ordered by score, ties keep their first-seen order
+//
+//		@Param			scanned	[]string			Directories found by scanning search paths
+//		@Param			sources	[]WeightedSource	Additional sources, failing ones are skipped
+//
+//		@Return			[]string	Deduplicated and ranked directories
+//		@Return			error		Errors of the skipped sources, nil if all loaded
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func MergeDirSources(scanned []string, sources []WeightedSource) ([]string, error) {
+	var errs []error
+	var order []string
+	scores := make(map[string]float64)
+
+	add := func(path string, score float64) {
+		path = filepath.Clean(path)
+		if _, ok := scores[path]; !ok {
+			order = append(order, path)
+		}
+		scores[path] += score
+	}
+
+	for _, dir := range scanned {
+		add(dir, 0)
+	}
+
+	for _, ws := range sources {
+		dirs, err := ws.Source.Dirs()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s source: %w", ws.Name, err))
+			continue
+		}
+		weight := ws.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		for _, d := range dirs {
+			if isDir(d.Path) {
+				add(d.Path, d.Score*weight)
+			}
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+	return order, errors.Join(errs...)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			commandSource reads directories from a shell command's output.
+//
+//		@Description	One directory per line, optionally prefixed by a score ("12.5 /path")
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type commandSource struct {
+	command string // Shell command to execute
+	scored  bool   // Whether lines start with a score
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Dirs runs the command and parses its output.
+//
+//		@Description	Unscored lines get a score of 1
+//
+//		@Return			[]ScoredDir	Directories from stdout
+//		@Return			error		Error with the command's stderr if it fails
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (s commandSource) Dirs() ([]ScoredDir, error) {
+	cmd := exec.Command("sh", "-c", s.command)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	var dirs []ScoredDir
+	for _, line := range nonEmptyLines(out.Bytes()) {
+		if !s.scored {
+			dirs = append(dirs, ScoredDir{Path: ExpandHome(line), Score: 1})
+			continue
+		}
+		score, path, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(score, 64)
+		if err != nil {
+			continue
+		}
+		dirs = append(dirs, ScoredDir{Path: strings.TrimSpace(path), Score: value})
+	}
+	return dirs, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			autojumpSource reads the autojump database ("score<TAB>path" lines).
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type autojumpSource struct {
+	path string
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Dirs parses the autojump database.
+//
+//		@Return			[]ScoredDir	Directories with their autojump weight
+//		@Return			error		Error if the database cannot be read
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (s autojumpSource) Dirs() ([]ScoredDir, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []ScoredDir
+	for _, line := range nonEmptyLines(data) {
+		score, path, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(score, 64)
+		if err != nil {
+			continue
+		}
+		dirs = append(dirs, ScoredDir{Path: path, Score: value})
+	}
+	return dirs, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			fasdSource reads the fasd database ("path|rank|time" lines).
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type fasdSource struct {
+	path string
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Dirs parses the fasd database.
+//
+//		@Description	fasd tracks files too, non-directories are dropped when merging
+//
+//		@Return			[]ScoredDir	Entries with their fasd rank
+//		@Return			error		Error if the database cannot be read
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (s fasdSource) Dirs() ([]ScoredDir, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []ScoredDir
+	for _, line := range nonEmptyLines(data) {
+		fields := strings.Split(line, "|")
+		if len(fields) < 2 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+		dirs = append(dirs, ScoredDir{Path: fields[0], Score: value})
+	}
+	return dirs, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			autojumpDataPath returns the default autojump database location.
+//
+//		@Return			string	Path to autojump.txt
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func autojumpDataPath() string {
+	base := os.Getenv("XDG_DATA_HOME")
+	if base == "" {
+		base = ExpandHome("~/.local/share")
+	}
+	return filepath.Join(base, "autojump", "autojump.txt")
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			fasdDataPath returns the default fasd database location.
+//
+//		@Return			string	$_FASD_DATA or ~/.fasd
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func fasdDataPath() string {
+	return firstNonEmpty(os.Getenv("_FASD_DATA"), ExpandHome("~/.fasd"))
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			nonEmptyLines splits data into trimmed, non-empty lines.
+//
+//		@Param			data	[]byte	Raw text
+//
+//		@Return			[]string	Lines
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func nonEmptyLines(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			firstNonEmpty returns the first non-empty string.
+//
+//		@Param			values	...string	Candidates
+//
+//		@Return			string	First non-empty value or empty string
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			isDir reports whether a path exists and is a directory.
+//
+//		@Param			path	string	Path to check
+//
+//		@Return			bool	True for existing directories
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
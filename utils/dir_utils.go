@@ -20,7 +20,7 @@ import (
 func ScanDirectories(basePath string, maxDepth int) []string {
 	var dirs []string
 
-	expanded := expandHome(basePath)
+	expanded := ExpandHome(basePath)
 
 	err := scanDir(expanded, expanded, maxDepth, 0, &dirs)
 	if err != nil {
@@ -79,14 +79,14 @@ func scanDir(basePath, currentPath string, maxDepth, currentDepth int, dirs *[]s
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
-//	 @Brief			expandHome expands ~ to the user's home directory.
+//	 @Brief			ExpandHome expands ~ to the user's home directory.
 //
 //		@Param			path	string	Path that may contain ~
 //
 //		@Return			string	Expanded path
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func expandHome(path string) string {
+func ExpandHome(path string) string {
 	if len(path) == 0 || path[0] != '~' {
 		return path
 	}
@@ -0,0 +1,154 @@
+package utils
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ParseEnvFile reads KEY=VALUE assignments from a .env or .envrc style file.
+//
+//		@Description	Blank lines, comments and lines that are not assignments (such as
+//		@Description	direnv's "use" or "layout") are skipped, a leading "export" is allowed.
+//		@Description	Values using command substitution are skipped as they need a shell.
+//		@Description	Single-quoted values are literal, other values expand $VAR and ${VAR}
+//		@Description	from earlier assignments and the environment
+//
+//		@Param			path	string		File to read
+//		@Param			vars	map[string]string	Variables to add to, earlier values are visible
+//
+//		@Return			[]string	Assigned keys in file order
+//		@Return			error		Error if the file cannot be read
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func ParseEnvFile(path string, vars map[string]string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var keys []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if !ok || !isEnvKey(key) || needsShell(value) {
+			continue
+		}
+		vars[key] = EnvValue(value, vars)
+		keys = append(keys, key)
+	}
+	return keys, scanner.Err()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			LoadEnvFiles reads the env files that exist in a directory.
+//
+//		@Param			dir		string				Directory holding the files
+//		@Param			names	[]string			File names, later files override earlier ones
+//		@Param			vars	map[string]string	Variables to add to
+//
+//		@Return			[]string	Assigned keys in order of first assignment
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func LoadEnvFiles(dir string, names []string, vars map[string]string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		assigned, err := ParseEnvFile(filepath.Join(dir, name), vars)
+		if err != nil {
+			continue
+		}
+		for _, k := range assigned {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	return keys
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			EnvValue unquotes and expands an env file or config value.
+//
+//		@Description	Text after the closing quote is ignored, a leading ~ is expanded
+//		@Description	to the home directory
+//
+//		@Param			value	string				Raw value
+//		@Param			vars	map[string]string	Variables visible to $VAR expansion
+//
+//		@Return			string	Final value
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func EnvValue(value string, vars map[string]string) string {
+	if value != "" && (value[0] == '\'' || value[0] == '"') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			quoted := value[1 : end+1]
+			if value[0] == '\'' {
+				return quoted
+			}
+			value = quoted
+		}
+	} else if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	value = os.Expand(value, func(name string) string {
+		if v, ok := vars[name]; ok {
+			return v
+		}
+		return os.Getenv(name)
+	})
+	return ExpandHome(value)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			needsShell reports whether a value uses command substitution.
+//
+//		@Description	Single-quoted values are literal and never need a shell
+//
+//		@Param			value	string	Raw value
+//
+//		@Return			bool	True for $(...) or backticks outside single quotes
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func needsShell(value string) bool {
+	if strings.HasPrefix(value, "'") {
+		return false
+	}
+	return strings.Contains(value, "$(") || strings.Contains(value, "`")
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			isEnvKey reports whether s is a valid environment variable name.
+//
+//		@Param			s	string	Candidate name
+//
+//		@Return			bool	True for letters, digits and underscores not starting with a digit
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func isEnvKey(s string) bool {
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		return false
+	}
+	for _, r := range s {
+		if r != '_' && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeEnvFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseEnvFile(t *testing.T) {
+	t.Setenv("HOME", "/home/tsm")
+	t.Setenv("TSM_TEST_OUTER", "outer")
+	path := writeEnvFile(t, t.TempDir(), ".envrc", `
+# comment
+use flake
+layout python3
+export PLAIN=value
+SPACED = padded
+COMMENTED=value # trailing comment
+DOUBLE="a # b"
+SINGLE='$PLAIN $(not run)'
+EXPANDED="${PLAIN}/$TSM_TEST_OUTER"
+HOME_DIR=~/src
+SUBSHELL=$(whoami)
+BACKTICK="`+"`whoami`"+`"
+1INVALID=x
+IN-VALID=x
+NOEQUALS
+`)
+	vars := map[string]string{}
+	keys, err := ParseEnvFile(path, vars)
+	if err != nil {
+		t.Fatalf("ParseEnvFile: %v", err)
+	}
+	wantKeys := []string{"PLAIN", "SPACED", "COMMENTED", "DOUBLE", "SINGLE", "EXPANDED", "HOME_DIR"}
+	if !slices.Equal(keys, wantKeys) {
+		t.Errorf("keys = %v, want %v", keys, wantKeys)
+	}
+	want := map[string]string{
+		"PLAIN":     "value",
+		"SPACED":    "padded",
+		"COMMENTED": "value",
+		"DOUBLE":    "a # b",
+		"SINGLE":    "$PLAIN $(not run)",
+		"EXPANDED":  "value/outer",
+		"HOME_DIR":  "/home/tsm/src",
+	}
+	if !maps.Equal(vars, want) {
+		t.Errorf("vars = %v, want %v", vars, want)
+	}
+}
+
+func TestParseEnvFileMissing(t *testing.T) {
+	if _, err := ParseEnvFile(filepath.Join(t.TempDir(), ".env"), map[string]string{}); err == nil {
+		t.Error("ParseEnvFile of a missing file returned no error")
+	}
+}
+
+func TestLoadEnvFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeEnvFile(t, dir, ".env", "A=env\nB=env\n")
+	writeEnvFile(t, dir, ".envrc", "B=envrc\nC=$A-$B\n")
+	vars := map[string]string{"A": "config"}
+	keys := LoadEnvFiles(dir, []string{".env", ".missing", ".envrc"}, vars)
+	if want := []string{"A", "B", "C"}; !slices.Equal(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+	want := map[string]string{"A": "env", "B": "envrc", "C": "env-envrc"}
+	if !maps.Equal(vars, want) {
+		t.Errorf("vars = %v, want %v", vars, want)
+	}
+}
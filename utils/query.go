@@ -0,0 +1,246 @@
+package utils
+
+import (
+	"strings"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			matchKind selects how a query term is compared against an item.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type matchKind int
+
+const (
+	matchExact  matchKind = iota // term or 'term: substring
+	matchPrefix                  // ^term: item starts with term
+	matchSuffix                  // term$: item ends with term
+	matchEqual                   // ^term$: item equals term
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			queryTerm is a single word of a query.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type queryTerm struct {
+	text   string    // Lowercased text without operators
+	kind   matchKind // Comparison to apply
+	negate bool      // Whether the term must not match
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Query is a parsed fzf-style extended search query.
+//
+//		@Description	Space-separated terms must all match, terms joined by "|" are alternatives
+//		@Description	Plain terms match substrings, ' is accepted for fzf habits
+//		@Description	Prefixes: ' exact, ^ prefix, ! negation; suffix: $ suffix
+//		@Description	Matching is case-insensitive
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type Query struct {
+	groups [][]queryTerm // AND of OR-groups
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			ParseQuery parses a search input into a Query.
+//
+//		@Description	Operators without text, such as a lone "^" or "!", are ignored
+//
+//		@Param			input	string	Raw search input
+//
+//		@Return			Query	Parsed query, empty queries match everything
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func ParseQuery(input string) Query {
+	var q Query
+	var group []queryTerm
+	joinNext := false
+	for _, word := range strings.Fields(input) {
+		if word == "|" {
+			joinNext = len(group) > 0
+			continue
+		}
+		t, ok := parseTerm(word)
+		if !ok {
+			continue
+		}
+		if !joinNext && len(group) > 0 {
+			q.groups = append(q.groups, group)
+			group = nil
+		}
+		group = append(group, t)
+		joinNext = false
+	}
+	if len(group) > 0 {
+		q.groups = append(q.groups, group)
+	}
+	return q
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Empty reports whether the query has no terms.
+//
+//		@Return			bool	True if every item matches
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (q Query) Empty() bool {
+	return len(q.groups) == 0
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Match reports whether an item satisfies the query.
+//
+//		@Param			item	string	Item to test
+//
+//		@Return			bool	True if every group has a matching term
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (q Query) Match(item string) bool {
+	s := strings.ToLower(item)
+	for _, group := range q.groups {
+		if !matchAny(group, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Filter keeps the items matching the query.
+//
+//		@Param			items	[]string	Items to filter
+//
+//		@Return			[]string	Matching items in their original order
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (q Query) Filter(items []string) []string {
+	return q.FilterBy(items, func(it string) string { return it })
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			FilterBy keeps the items whose label matches the query.
+//
+//		@Description	Lets callers match what they display, e.g. a directory's basename
+//		@Description	instead of its absolute path
+//
+//		@Param			items	[]string				Items to filter
+//		@Param			label	func(string) string	Text to match for an item
+//
+//		@Return			[]string	Matching items in their original order
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (q Query) FilterBy(items []string, label func(string) string) []string {
+	if q.Empty() {
+		return items
+	}
+	out := make([]string, 0, len(items))
+	for _, it := range items {
+		if q.Match(label(it)) {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			highlightRunes returns the characters of all positive terms.
+//
+//		@Return			map[rune]struct{}	Lowercase runes to highlight
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (q Query) highlightRunes() map[rune]struct{} {
+	var b strings.Builder
+	for _, group := range q.groups {
+		for _, t := range group {
+			if !t.negate {
+				b.WriteString(t.text)
+			}
+		}
+	}
+	return RuneSetFold(b.String())
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			parseTerm parses the operators of a single query word.
+//
+//		@Param			word	string	Query word
+//
+//		@Return			queryTerm	Parsed term
+//		@Return			bool		False if no text is left after the operators
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func parseTerm(word string) (queryTerm, bool) {
+	t := queryTerm{kind: matchExact}
+	if rest, ok := strings.CutPrefix(word, "!"); ok {
+		t.negate = true
+		word = rest
+	}
+	if rest, ok := strings.CutPrefix(word, "'"); ok {
+		word = rest
+	} else if rest, ok := strings.CutPrefix(word, "^"); ok {
+		t.kind = matchPrefix
+		word = rest
+	}
+	if rest, ok := strings.CutSuffix(word, "$"); ok {
+		if t.kind == matchPrefix {
+			t.kind = matchEqual
+		} else {
+			t.kind = matchSuffix
+		}
+		word = rest
+	}
+	t.text = strings.ToLower(word)
+	return t, t.text != ""
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			matchAny reports whether any term of an OR-group matches.
+//
+//		@Param			group	[]queryTerm	Alternatives
+//		@Param			s		string		Lowercased item
+//
+//		@Return			bool	True if one alternative matches
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func matchAny(group []queryTerm, s string) bool {
+	for _, t := range group {
+		if t.match(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			match tests a single term against a lowercased item.
+//
+//		@Param			s	string	Lowercased item
+//
+//		@Return			bool	True if the term is satisfied (inverted for negated terms)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (t queryTerm) match(s string) bool {
+	var ok bool
+	switch t.kind {
+	case matchExact:
+		ok = strings.Contains(s, t.text)
+	case matchPrefix:
+		ok = strings.HasPrefix(s, t.text)
+	case matchSuffix:
+		ok = strings.HasSuffix(s, t.text)
+	case matchEqual:
+		ok = s == t.text
+	}
+	return ok != t.negate
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestQueryMatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		item  string
+		want  bool
+	}{
+		{"empty matches everything", "", "api", true},
+		{"blank matches everything", "   ", "api", true},
+		{"plain term is a substring", "pi", "API-server", true},
+		{"plain term does not fuzzy match", "asr", "api-server", false},
+		{"quote is a substring", "'pi", "api", true},
+		{"quote requires contiguous text", "'asr", "api-server", false},
+		{"prefix matches start", "^api", "api-server", true},
+		{"prefix rejects middle", "^server", "api-server", false},
+		{"suffix matches end", "server$", "api-server", true},
+		{"suffix rejects middle", "api$", "api-server", false},
+		{"prefix and suffix match whole item", "^api$", "api", true},
+		{"prefix and suffix reject longer item", "^api$", "api-server", false},
+		{"quote with suffix matches end", "'ver$", "api-server", true},
+		{"quote with suffix rejects middle", "'api$", "api-server", false},
+		{"negation excludes substring", "!test", "api-test", false},
+		{"negation keeps others", "!test", "api", true},
+		{"negated prefix", "!^api", "web-api", true},
+		{"negated equal excludes item", "!^x$", "x", false},
+		{"negated equal keeps longer item", "!^x$", "xy", true},
+		{"and requires every term", "api srv", "api-server", false},
+		{"and with every term present", "api ser", "api-server", true},
+		{"and with negation", "api !web", "web-api", false},
+		{"or matches either side", "web | api", "api", true},
+		{"or matches neither side", "web | api", "db", false},
+		{"or binds tighter than and", "web | api ser", "api-server", true},
+		{"or group still needs the and term", "web | api db", "api-server", false},
+		{"case is ignored", "^API", "api-server", true},
+		{"leading bar is ignored", "| api", "api", true},
+		{"trailing bar is ignored", "api |", "api", true},
+		{"repeated bars join once", "web | | api", "api", true},
+		{"lone caret is ignored", "^ api", "web-api", true},
+		{"lone bang is ignored", "! api", "api", true},
+		{"lone quote is ignored", "' api", "api", true},
+		{"lone dollar is ignored", "$ api", "api", true},
+		{"caret dollar is ignored", "^$", "api", true},
+		{"bang caret is ignored", "!^", "api", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseQuery(tt.query).Match(tt.item); got != tt.want {
+				t.Errorf("ParseQuery(%q).Match(%q) = %v, want %v", tt.query, tt.item, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryEmpty(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"^ ! ' $ |", true},
+		{"api", false},
+		{"| api", false},
+	}
+	for _, tt := range tests {
+		if got := ParseQuery(tt.query).Empty(); got != tt.want {
+			t.Errorf("ParseQuery(%q).Empty() = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestQueryFilterBy(t *testing.T) {
+	items := []string{"/src/api", "/src/web", "/api/web"}
+	base := func(s string) string { return s[len("/src/"):] }
+	got := ParseQuery("^web").FilterBy(items, base)
+	want := []string{"/src/web", "/api/web"}
+	if !slices.Equal(got, want) {
+		t.Errorf("FilterBy = %v, want %v", got, want)
+	}
+}
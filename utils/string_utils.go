@@ -14,23 +14,38 @@ import (
 // ///////////////////////////////////////////////////////////////////////////////////////////
 var MatchStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			SetMatchColor changes the color used for highlighting matched characters.
+//
+//		@Param			color	lipgloss.Color	Highlight color, empty keeps the current one
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func SetMatchColor(color lipgloss.Color) {
+	if color == "" {
+		return
+	}
+	MatchStyle = lipgloss.NewStyle().Foreground(color)
+}
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	 @Brief			HighlightMatches highlights characters in item that match the query.
 //
 //		@Description	Uses MatchStyle to highlight matching characters
+//		@Description	Operators and negated terms of the query are not highlighted
 //
 //		@Param			item	string	String to highlight
-//		@Param			query	string	Search query for matching
+//		@Param			query	string	Search query in ParseQuery syntax
 //
 //		@Return			string	String with highlighted matches
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func HighlightMatches(item, query string) string {
-	if query == "" {
+	qset := ParseQuery(query).highlightRunes()
+	if len(qset) == 0 {
 		return item
 	}
-	qset := RuneSetFold(query)
 	var b strings.Builder
 	for _, r := range item {
 		if _, ok := qset[unicode.ToLower(r)]; ok {
@@ -46,7 +61,7 @@ func HighlightMatches(item, query string) string {
 //
 //	 @Brief			FuzzyFilter filters items based on fuzzy string matching.
 //
-//		@Description	Returns items where query appears as substring (case-insensitive)
+//		@Description	Parses query with ParseQuery, so fzf-style operators are supported
 //
 //		@Param			items	[]string	Items to filter
 //		@Param			query	string		Search query
@@ -55,17 +70,22 @@ func HighlightMatches(item, query string) string {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func FuzzyFilter(items []string, query string) []string {
-	if query == "" {
-		return items
-	}
-	q := strings.ToLower(query)
-	out := make([]string, 0, len(items))
-	for _, it := range items {
-		if strings.Contains(strings.ToLower(it), q) {
-			out = append(out, it)
-		}
-	}
-	return out
+	return ParseQuery(query).Filter(items)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			FuzzyFilterBy filters items by matching the query against their labels.
+//
+//		@Param			items	[]string				Items to filter
+//		@Param			query	string					Search query
+//		@Param			label	func(string) string	Displayed text of an item
+//
+//		@Return			[]string	Filtered items
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func FuzzyFilterBy(items []string, query string, label func(string) string) []string {
+	return ParseQuery(query).FilterBy(items, label)
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
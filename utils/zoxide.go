@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+const (
+	zoxideCommand   = "zoxide query --list --score"
+	zoxideDBVersion = 3
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			zoxideSource reads zoxide's directories.
+//
+//		@Description	Runs zoxide when no database is configured and it is installed,
+//		@Description	reads the database file directly otherwise
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type zoxideSource struct {
+	path string // Database file override, empty to ask zoxide
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			Dirs queries zoxide or parses its database.
+//
+//		@Return			[]ScoredDir	Directories with their zoxide score
+//		@Return			error		Error if zoxide fails or the database cannot be read
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (s zoxideSource) Dirs() ([]ScoredDir, error) {
+	path := s.path
+	if path == "" {
+		if _, err := exec.LookPath("zoxide"); err == nil {
+			return commandSource{command: zoxideCommand, scored: true}.Dirs()
+		}
+		path = zoxideDataPath()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseZoxideDB(data, time.Now())
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			parseZoxideDB decodes a zoxide database (db.zo).
+//
+//		@Description	The file holds a little-endian u32 version followed by the directories:
+//		@Description	a u64 count, then per directory a u64-prefixed path, an f64 rank and
+//		@Description	a u64 access time. Ranks are aged like "zoxide query --score" does
+//
+//		@Param			data	[]byte		Database contents
+//		@Param			now		time.Time	Reference time for aging
+//
+//		@Return			[]ScoredDir	Directories with their aged rank
+//		@Return			error		Error for unknown versions or truncated files
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func parseZoxideDB(data []byte, now time.Time) ([]ScoredDir, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	r := bytes.NewReader(data)
+	var version uint32
+	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
+		return nil, fmt.Errorf("zoxide database: %w", err)
+	}
+	if version != zoxideDBVersion {
+		return nil, fmt.Errorf("zoxide database: unsupported version %d", version)
+	}
+	var count uint64
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, fmt.Errorf("zoxide database: %w", err)
+	}
+	var dirs []ScoredDir
+	for i := uint64(0); i < count; i++ {
+		var size uint64
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+			return nil, fmt.Errorf("zoxide database: %w", err)
+		}
+		if size > uint64(r.Len()) {
+			return nil, fmt.Errorf("zoxide database: truncated path")
+		}
+		path := make([]byte, size)
+		r.Read(path)
+		var entry struct {
+			Rank         float64
+			LastAccessed uint64
+		}
+		if err := binary.Read(r, binary.LittleEndian, &entry); err != nil {
+			return nil, fmt.Errorf("zoxide database: %w", err)
+		}
+		if math.IsNaN(entry.Rank) {
+			continue
+		}
+		age := now.Sub(time.Unix(int64(entry.LastAccessed), 0))
+		dirs = append(dirs, ScoredDir{Path: string(path), Score: entry.Rank * zoxideAging(age)})
+	}
+	return dirs, nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			zoxideAging returns zoxide's multiplier for the time since the last visit.
+//
+//		@Param			age		time.Duration	Time since the directory was last accessed
+//
+//		@Return			float64	4 within an hour, 2 within a day, 0.5 within a week, 0.25 after
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func zoxideAging(age time.Duration) float64 {
+	switch {
+	case age < time.Hour:
+		return 4
+	case age < 24*time.Hour:
+		return 2
+	case age < 7*24*time.Hour:
+		return 0.5
+	default:
+		return 0.25
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			zoxideDataPath returns the default zoxide database location.
+//
+//		@Return			string	$_ZO_DATA_DIR/db.zo, or db.zo in zoxide's data directory
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func zoxideDataPath() string {
+	if dir := os.Getenv("_ZO_DATA_DIR"); dir != "" {
+		return filepath.Join(dir, "db.zo")
+	}
+	base := os.Getenv("XDG_DATA_HOME")
+	if base == "" {
+		base = ExpandHome("~/.local/share")
+	}
+	return filepath.Join(base, "zoxide", "db.zo")
+}
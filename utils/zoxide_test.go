@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// zoxideFixture is a version 3 db.zo with "/tmp" (rank 2, accessed at 1000000) and
+// "/srv" (rank 1, accessed at 0), laid out as bincode writes zoxide's Vec<Dir>.
+var zoxideFixture = []byte{
+	0x03, 0x00, 0x00, 0x00, // version
+	0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // directory count
+	0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, '/', 't', 'm', 'p', // path
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x40, // rank 2.0
+	0x40, 0x42, 0x0f, 0x00, 0x00, 0x00, 0x00, 0x00, // last accessed 1000000
+	0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, '/', 's', 'r', 'v', // path
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0x3f, // rank 1.0
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // last accessed 0
+}
+
+func TestParseZoxideDB(t *testing.T) {
+	now := time.Unix(1000000+60, 0)
+	got, err := parseZoxideDB(zoxideFixture, now)
+	if err != nil {
+		t.Fatalf("parseZoxideDB: %v", err)
+	}
+	want := []ScoredDir{
+		{Path: "/tmp", Score: 2 * 4},    // accessed a minute ago
+		{Path: "/srv", Score: 1 * 0.25}, // accessed long ago
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("parseZoxideDB = %v, want %v", got, want)
+	}
+}
+
+func TestParseZoxideDBErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"truncated version", zoxideFixture[:2]},
+		{"truncated count", zoxideFixture[:8]},
+		{"truncated path", zoxideFixture[:22]},
+		{"truncated rank", zoxideFixture[:28]},
+		{"missing second directory", zoxideFixture[:40]},
+		{"unsupported version", append([]byte{0x02, 0x00, 0x00, 0x00}, zoxideFixture[4:]...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if dirs, err := parseZoxideDB(tt.data, time.Unix(0, 0)); err == nil {
+				t.Errorf("parseZoxideDB = %v, want an error", dirs)
+			}
+		})
+	}
+}
+
+func TestParseZoxideDBEmpty(t *testing.T) {
+	dirs, err := parseZoxideDB(nil, time.Unix(0, 0))
+	if err != nil || dirs != nil {
+		t.Errorf("parseZoxideDB(nil) = %v, %v, want nil, nil", dirs, err)
+	}
+}
+
+func TestZoxideAging(t *testing.T) {
+	tests := []struct {
+		age  time.Duration
+		want float64
+	}{
+		{time.Minute, 4},
+		{2 * time.Hour, 2},
+		{3 * 24 * time.Hour, 0.5},
+		{30 * 24 * time.Hour, 0.25},
+	}
+	for _, tt := range tests {
+		if got := zoxideAging(tt.age); got != tt.want {
+			t.Errorf("zoxideAging(%v) = %v, want %v", tt.age, got, tt.want)
+		}
+	}
+}
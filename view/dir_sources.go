@@ -0,0 +1,93 @@
+package view
+
+import (
+	"log"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jkeresman01/tsm/config"
+	modes "github.com/jkeresman01/tsm/modes"
+	"github.com/jkeresman01/tsm/utils"
+)
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			dirSourcesMsg carries the project directories merged with the sources.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type dirSourcesMsg struct {
+	dirs []string
+	err  error
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			dirSources creates the directory sources of the configuration.
+//
+//		@Description	Unknown source types are logged and skipped
+//
+//		@Param			cfg		config.Config	Application configuration
+//		@Param			logger	*log.Logger		Log for invalid sources
+//
+//		@Return			[]utils.WeightedSource	Valid sources
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func dirSources(cfg config.Config, logger *log.Logger) []utils.WeightedSource {
+	var sources []utils.WeightedSource
+	for _, sc := range cfg.Sources {
+		src, err := utils.NewDirSource(sc.Type, sc.Command, sc.Path)
+		if err != nil {
+			logger.Println("directory sources:", err)
+			continue
+		}
+		sources = append(sources, utils.WeightedSource{Name: sc.Type, Source: src, Weight: sc.Weight})
+	}
+	return sources
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			loadDirSources merges the sources into the scanned directories.
+//
+//		@Description	Runs outside the UI loop, since sources may run commands such as zoxide
+//
+//		@Param			scanned	[]string				Directories found by scanning search paths
+//		@Param			sources	[]utils.WeightedSource	Sources to merge
+//
+//		@Return			tea.Cmd		Command returning a dirSourcesMsg, nil without sources
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func loadDirSources(scanned []string, sources []utils.WeightedSource) tea.Cmd {
+	if len(sources) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		dirs, err := utils.MergeDirSources(scanned, sources)
+		return dirSourcesMsg{dirs: dirs, err: err}
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			handleDirSources replaces the directories once the sources are loaded.
+//
+//		@Description	Failing sources are logged, an open create mode is updated in place
+//
+//		@Param			msg		dirSourcesMsg	Merged directories
+//
+//		@Return			tea.Cmd		Command of the updated create mode
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) handleDirSources(msg dirSourcesMsg) tea.Cmd {
+	if msg.err != nil {
+		m.log.Println("directory sources:", msg.err)
+	}
+	if len(msg.dirs) == 0 {
+		return nil
+	}
+	m.dirs = msg.dirs
+	if c, ok := m.mode.(*modes.CreateMode); ok {
+		return c.SetDirs(msg.dirs)
+	}
+	return nil
+}
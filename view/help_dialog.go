@@ -3,6 +3,7 @@ package view
 import (
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jkeresman01/tsm/styles"
 	"github.com/jkeresman01/tsm/view/model"
@@ -14,28 +15,52 @@ const helpKeyColWidth = 14
 //
 //	    @Brief			RenderHelpDialog renders the help dialog with keyboard shortcuts.
 //
-//		@Param			width	int		Width of the dialog
+//		@Param			width	int						Width of the dialog
+//		@Param			groups	[]model.ShortcutGroup	Shortcut sections to display
+//		@Param			filter	textinput.Model			Filter input narrowing the shortcuts
 //
 //		@Return			string	Rendered help dialog
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func RenderHelpDialog(width int) string {
-	content := helpContent()
+func RenderHelpDialog(width int, groups []model.ShortcutGroup, filter textinput.Model) string {
+	content := helpContent(groups, filter)
 	return styles.HelpBoxStyle.Width(width).Render(content)
 }
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	    @Brief			newHelpFilter creates the text input used to filter shortcuts.
+//
+//		@Return			textinput.Model	Configured input field
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func newHelpFilter() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "Type to filter..."
+	ti.Prompt = styles.CurrentIcons.Search + " "
+	ti.CharLimit = 32
+	ti.Width = 30
+	ti.Focus()
+	return ti
+}
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	    @Brief			helpContent generates the complete help dialog content.
 //
-//		@Return			string	Help dialog content with title and shortcuts
+//		@Param			groups	[]model.ShortcutGroup	Shortcut sections to display
+//		@Param			filter	textinput.Model			Filter input narrowing the shortcuts
+//
+//		@Return			string	Help dialog content with title, filter and shortcuts
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func helpContent() string {
+func helpContent(groups []model.ShortcutGroup, filter textinput.Model) string {
 	var b strings.Builder
 	b.WriteString(helpTitle())
 	b.WriteString("\n\n")
-	b.WriteString(helpLines())
+	b.WriteString(filter.View())
+	b.WriteString("\n\n")
+	b.WriteString(helpGroups(filterShortcutGroups(groups, filter.Value())))
 	return b.String()
 }
 
@@ -52,14 +77,41 @@ func helpTitle() string {
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
-//	    @Brief			helpLines renders all shortcut lines.
+//	    @Brief			helpGroups renders all shortcut sections.
+//
+//		@Param			groups	[]model.ShortcutGroup	Shortcut sections to render
+//
+//		@Return			string	All sections with their headings, or a no-match notice
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func helpGroups(groups []model.ShortcutGroup) string {
+	if len(groups) == 0 {
+		return styles.HelpDescStyle.Render("No matching shortcuts") + "\n"
+	}
+	var b strings.Builder
+	for i, g := range groups {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(styles.HelpGroupStyle.Render(g.Title))
+		b.WriteByte('\n')
+		b.WriteString(helpLines(g.Shortcuts))
+	}
+	return b.String()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	    @Brief			helpLines renders all shortcut lines of a section.
+//
+//		@Param			shortcuts	[]model.Shortcut	Shortcuts to render
 //
 //		@Return			string	All shortcut lines formatted
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func helpLines() string {
+func helpLines(shortcuts []model.Shortcut) string {
 	var b strings.Builder
-	for _, sc := range model.Shortcuts {
+	for _, sc := range shortcuts {
 		b.WriteString(helpLine(sc))
 		b.WriteByte('\n')
 	}
@@ -76,7 +128,39 @@ func helpLines() string {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func helpLine(sc model.Shortcut) string {
-	key := styles.HelpKeyStyle.Width(helpKeyColWidth).Render(sc.Key)
-	desc := styles.HelpDescStyle.Render(sc.Desc)
+	key := styles.HelpKeyStyle.Width(helpKeyColWidth).Render(styles.CurrentIcons.Text(sc.Key))
+	desc := styles.HelpDescStyle.Render(styles.CurrentIcons.Text(sc.Desc))
 	return lipgloss.JoinHorizontal(lipgloss.Top, key, desc)
 }
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	    @Brief			filterShortcutGroups keeps shortcuts whose key or description match the query.
+//
+//		@Description	Matching is case-insensitive, sections left empty are dropped
+//
+//		@Param			groups	[]model.ShortcutGroup	Shortcut sections to filter
+//		@Param			query	string					Filter text
+//
+//		@Return			[]model.ShortcutGroup	Filtered sections
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func filterShortcutGroups(groups []model.ShortcutGroup, query string) []model.ShortcutGroup {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return groups
+	}
+	out := make([]model.ShortcutGroup, 0, len(groups))
+	for _, g := range groups {
+		var matched []model.Shortcut
+		for _, sc := range g.Shortcuts {
+			if strings.Contains(strings.ToLower(sc.Key+" "+sc.Desc), q) {
+				matched = append(matched, sc)
+			}
+		}
+		if len(matched) > 0 {
+			out = append(out, model.ShortcutGroup{Title: g.Title, Shortcuts: matched})
+		}
+	}
+	return out
+}
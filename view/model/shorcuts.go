@@ -12,19 +12,29 @@ type Shortcut struct {
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
-//	@Brief 		Shortcuts contains all keyboard shortcuts for the application.
+//	@Brief			ShortcutGroup is a titled section of shortcuts in the help dialog.
 //
-//	@Description	Displayed in the help dialog when user presses '?'
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type ShortcutGroup struct {
+	Title     string
+	Shortcuts []Shortcut
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief 		GlobalShortcuts contains keyboard shortcuts available in every mode.
+//
+//	@Description	Displayed in the help dialog above the current mode's own shortcuts
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-var Shortcuts = []Shortcut{
-	{"↑ / k", "Move up"},
-	{"↓ / j", "Move down"},
-	{"Enter", "Select / Confirm"},
+var GlobalShortcuts = []Shortcut{
 	{"Tab", "Cycle mode"},
-	{"Ctrl+N", "Create new session"},
-	{"Ctrl+R", "Rename selected session"},
-	{"Ctrl+S", "Switch to selected session"},
+	{"Click mode", "Cycle mode"},
+	{"Ctrl+N", "Go to create mode"},
+	{"Ctrl+R", "Go to rename mode"},
+	{"Ctrl+S", "Go to switch mode"},
+	{"Ctrl+K", "Go to marks mode"},
+	{"Ctrl+V", "Go to servers mode"},
 	{"q / Ctrl+C", "Quit"},
 	{"?", "Toggle help"},
 }
@@ -1,8 +1,13 @@
 package view
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jkeresman01/tsm/config"
@@ -10,6 +15,7 @@ import (
 	styles "github.com/jkeresman01/tsm/styles"
 	"github.com/jkeresman01/tsm/tmux"
 	"github.com/jkeresman01/tsm/utils"
+	"github.com/jkeresman01/tsm/view/model"
 )
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -20,31 +26,89 @@ import (
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 type manager struct {
-	width    int                // Terminal width
-	height   int                // Terminal height
-	showHelp bool               // Whether help dialog is visible
-	mode     modes.ModeStrategy // Current operational mode
-	dirs     []string           // Available project directories
+	width      int                    // Terminal width
+	height     int                    // Terminal height
+	showHelp   bool                   // Whether help dialog is visible
+	helpFilter textinput.Model        // Filter input of the help dialog
+	mode       modes.ModeStrategy     // Current operational mode
+	dirs       []string               // Available project directories
+	sources    []utils.WeightedSource // External directory sources, loaded by Init
+	log        *log.Logger            // Log for errors that are not shown in the UI
+	watcher    *tmux.Watcher          // Control-mode client reporting session changes
+	watched    tmux.Server            // Server the watcher is attached to
+	sessions   []string               // Session names, kept current by the watcher
+	notice     string                 // Startup notice shown in the footer until a key is pressed
+	banner     string                 // Explanation shown above the body while there are no sessions
+	origin     frameOrigin            // Screen position of the last rendered frame, for mouse events
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			frameOrigin is where the rendered frame's content starts on screen.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type frameOrigin struct {
+	x    int // Column of the first content cell
+	y    int // Row of the first content line (the header)
+	body int // Row of the mode's first line, below the header and banner
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	@Brief			NewTsmManager creates a new TSM manager instance.
 //
+//	@Description	Starts in create mode with an explanatory banner when there are no sessions
+//
 //	@Param			cfg		config.Config	Application configuration
+//	@Param			logger	*log.Logger		Log for errors such as failing directory sources
 //
 //	@Return	    tea.Model		Initialized Bubble Tea model
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func NewTsmManager(cfg config.Config) tea.Model {
-	sessions, _ := tmux.ListSessions()
+func NewTsmManager(cfg config.Config, logger *log.Logger) tea.Model {
+	sessions, err := tmux.ListSessions()
 	if len(sessions) == 0 {
 		sessions = []string{}
 	}
-	dirs := utils.GetProjectDirs(cfg.SearchPaths, cfg.MaxDepth)
-	return &manager{
-		mode: modes.NewSwitchMode(sessions),
-		dirs: dirs,
+	dirs, _ := utils.MergeDirSources(utils.GetProjectDirs(cfg.SearchPaths, cfg.MaxDepth), nil)
+	modes.SetSearchRoots(cfg.SearchPaths, cfg.GroupByRoot)
+	m := &manager{
+		mode:       modes.NewSwitchMode(sessions),
+		dirs:       dirs,
+		sources:    dirSources(cfg, logger),
+		log:        logger,
+		helpFilter: newHelpFilter(),
+		notice:     tmux.Notice(),
+		banner:     bannerFor(sessions, err),
+	}
+	if m.banner != "" {
+		m.mode = modes.NewCreateMode(dirs)
+	}
+	return m
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			bannerFor explains why there are no sessions to switch to.
+//
+//	@Param			sessions	[]string	Sessions listed by tmux
+//	@Param			err			error		Error from listing them
+//
+//	@Return			string		Banner text, empty if there are sessions
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func bannerFor(sessions []string, err error) string {
+	switch {
+	case len(sessions) > 0:
+		return ""
+	case errors.Is(err, tmux.ErrNotInstalled):
+		return "tmux is not installed or not in $PATH"
+	case errors.Is(err, tmux.ErrNoServer):
+		return fmt.Sprintf("No tmux server running on %q • pick a directory to start one", tmux.CurrentServer().Label())
+	case err != nil:
+		return "tmux: " + err.Error()
+	default:
+		return "No tmux sessions yet • pick a directory to create one"
 	}
 }
 
@@ -52,15 +116,19 @@ func NewTsmManager(cfg config.Config) tea.Model {
 //
 //	@Brief			Init initializes the manager (Bubble Tea Init method).
 //
-//	@Return	    tea.Cmd	Initial command (nil in this case)
+//	@Return	    tea.Cmd	Initial commands of the mode, the session watcher and the sources
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func (m *manager) Init() tea.Cmd { return nil }
+func (m *manager) Init() tea.Cmd {
+	return tea.Batch(m.mode.Init(), startWatcher, loadDirSources(m.dirs, m.sources))
+}
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	@Brief			Update processes messages and updates the manager state.
 //
+//	@Description	Runs the Init command of a mode whenever the active mode changes
+//
 //	@Param			msg		tea.Msg		Input message
 //
 //	@Return			tea.Model	Updated model
@@ -68,18 +136,62 @@ func (m *manager) Init() tea.Cmd { return nil }
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *manager) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	prev := m.mode
+	cmd := m.update(msg)
+	if m.mode != prev {
+		cmd = tea.Batch(cmd, m.mode.Init())
+		if m.banner != "" {
+			sessions, err := tmux.ListSessions()
+			m.banner = bannerFor(sessions, err)
+		}
+	}
+	m.followServer()
+	return m, cmd
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			update dispatches a message to the manager or the current mode.
+//
+//	@Param			msg		tea.Msg		Input message
+//
+//	@Return			tea.Cmd		Command to execute
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) update(msg tea.Msg) tea.Cmd {
 	switch t := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.applyWindowSize(t)
-		return m, nil
+		return nil
+	case watcherStartedMsg, watcherEventMsg, watcherClosedMsg:
+		return m.handleWatcher(t)
+	case dirSourcesMsg:
+		return m.handleDirSources(t)
+	case modes.SessionsChangedMsg:
+		m.sessions = t.Sessions
+		if len(t.Sessions) > 0 {
+			m.banner = ""
+		}
+	case tea.MouseMsg:
+		return m.handleMouse(t)
 	case tea.KeyMsg:
-		if cmd := m.handleGlobalKey(t); cmd != nil {
-			return m, cmd
+		m.notice = ""
+		if m.showHelp {
+			return m.handleHelpKey(t)
+		}
+		if m.modeCapturesInput() {
+			if t.String() == "ctrl+c" {
+				return tea.Quit
+			}
+			break
+		}
+		if cmd, handled := m.handleGlobalKey(t); handled {
+			return cmd
 		}
 	}
 	newMode, cmd := m.mode.Update(msg)
 	m.mode = newMode
-	return m, cmd
+	return cmd
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -103,17 +215,34 @@ func (m *manager) View() string {
 //
 //	 @Brief			renderLayout renders the main application layout.
 //
+//		@Description	Remembers where the frame landed, so mouse events need no render
+//
 //		@Return			string	Complete rendered layout with header, body, and footer
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *manager) renderLayout() string {
+	frame := m.renderFrame()
+	m.origin = m.locateFrame(frame)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, frame)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			renderFrame renders the bordered application frame before centering.
+//
+//		@Return			string	Header, body and footer inside the outer container
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) renderFrame() string {
 	header := m.renderHeader()
+	if m.banner != "" {
+		header = lipgloss.JoinVertical(lipgloss.Top, header, m.renderBanner())
+	}
 	body := m.renderBody()
 	footer := m.renderFooter()
 	padding := strings.Repeat("\n", m.remainingHeight(lipgloss.Height(header)+lipgloss.Height(body)+lipgloss.Height(footer)))
 	layout := lipgloss.JoinVertical(lipgloss.Top, header, body, padding, footer)
-	withOuter := styles.CurrentTheme.OuterStyle.Render(layout)
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, withOuter)
+	return styles.CurrentTheme.OuterStyle.Render(layout)
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -135,12 +264,13 @@ func (m *manager) applyWindowSize(msg tea.WindowSizeMsg) {
 //	@Param			k		tea.KeyMsg	Keyboard message
 //
 //	@Return	    tea.Cmd	Command to execute (e.g., tea.Quit)
+//	@Return	    bool	Whether the key was a global shortcut
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
-func (m *manager) handleGlobalKey(k tea.KeyMsg) tea.Cmd {
+func (m *manager) handleGlobalKey(k tea.KeyMsg) (tea.Cmd, bool) {
 	switch k.String() {
 	case "ctrl+c", "q":
-		return tea.Quit
+		return tea.Quit, true
 	case "?":
 		m.showHelp = !m.showHelp
 	case "tab":
@@ -151,8 +281,131 @@ func (m *manager) handleGlobalKey(k tea.KeyMsg) tea.Cmd {
 		m.mode = modes.NewRenameMode("")
 	case "ctrl+s":
 		m.handleSwitchMode()
+	case "ctrl+k":
+		m.mode = modes.NewMarksMode()
+	case "ctrl+v":
+		m.mode = modes.NewServersMode()
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			handleMouse processes mouse events.
+//
+//	@Description	Clicking the mode indicator cycles modes
+//	@Description	Other events are forwarded to the mode relative to its view
+//
+//	@Param			msg		tea.MouseMsg	Mouse event in terminal coordinates
+//
+//	@Return	    tea.Cmd	Command from the mode
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	if m.showHelp || m.width == 0 || m.height == 0 {
+		return nil
+	}
+	if m.isModeIndicatorClick(msg, m.origin.x, m.origin.y) {
+		m.cycleMode()
+		return nil
+	}
+	msg.X -= m.origin.x + styles.CurrentTheme.ListStyle.GetPaddingLeft()
+	msg.Y -= m.origin.body
+	newMode, cmd := m.mode.Update(msg)
+	m.mode = newMode
+	return cmd
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			isModeIndicatorClick reports whether a click hit the header's mode indicator.
+//
+//	@Param			msg			tea.MouseMsg	Mouse event in terminal coordinates
+//	@Param			contentX	int				Left edge of the frame content
+//	@Param			contentY	int				Top edge of the frame content
+//
+//	@Return	    bool	True for a left click on the mode indicator
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) isModeIndicatorClick(msg tea.MouseMsg, contentX, contentY int) bool {
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return false
+	}
+	indicatorX := contentX + styles.CurrentTheme.HeaderStyle.GetPaddingLeft() + styles.CurrentTheme.LeftPanelWidth
+	return msg.Y == contentY && msg.X >= indicatorX && msg.X < contentX+m.totalContentWidth()
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			locateFrame locates the content of a rendered frame on screen.
+//
+//	@Param			frame	string	Output of renderFrame
+//
+//	@Return	    frameOrigin	Content, header and body positions
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) locateFrame(frame string) frameOrigin {
+	outer := styles.CurrentTheme.OuterStyle
+	x := centerOffset(m.width, lipgloss.Width(frame)) + outer.GetMarginLeft() + outer.GetBorderLeftSize() + outer.GetPaddingLeft()
+	y := centerOffset(m.height, lipgloss.Height(frame)) + outer.GetMarginTop() + outer.GetBorderTopSize() + outer.GetPaddingTop()
+	return frameOrigin{x: x, y: y, body: y + lipgloss.Height(m.renderHeader()) + m.bannerHeight()}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			centerOffset mirrors lipgloss.Place centering for a single axis.
+//
+//	@Param			total	int		Available cells
+//	@Param			size	int		Cells taken by the content
+//
+//	@Return	    int		Cells before the content
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func centerOffset(total, size int) int {
+	gap := total - size
+	if gap <= 0 {
+		return 0
 	}
-	return nil
+	return gap - int(math.Round(float64(gap)*0.5))
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			handleHelpKey processes keyboard input while the help dialog is open.
+//
+//	@Description	Printable keys go to the help filter instead of the current mode
+//
+//	@Param			k		tea.KeyMsg	Keyboard message
+//
+//	@Return	    tea.Cmd	Command to execute (e.g., tea.Quit)
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) handleHelpKey(k tea.KeyMsg) tea.Cmd {
+	switch k.String() {
+	case "ctrl+c":
+		return tea.Quit
+	case "?", "esc":
+		m.showHelp = false
+		m.helpFilter.Reset()
+		return nil
+	}
+	var cmd tea.Cmd
+	m.helpFilter, cmd = m.helpFilter.Update(k)
+	return cmd
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			modeCapturesInput reports whether the current mode owns the keyboard.
+//
+//	@Return	    bool	True if global shortcuts must be bypassed
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) modeCapturesInput() bool {
+	c, ok := m.mode.(modes.InputCapturer)
+	return ok && c.CapturesInput()
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
@@ -176,28 +429,54 @@ func (m *manager) handleCreateMode() {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *manager) handleSwitchMode() {
+	m.mode = modes.NewSwitchMode(m.listSessions())
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			listSessions returns the session names without asking tmux when possible.
+//
+//	@Description	While the watcher runs, the list from its last notification is current
+//
+//	@Return			[]string	Session names
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) listSessions() []string {
+	if m.watcher != nil && m.sessions != nil {
+		return m.sessions
+	}
 	sessions, _ := tmux.ListSessions()
-	m.mode = modes.NewSwitchMode(sessions)
+	return sessions
 }
 
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //		 @Brief			cycleMode cycles through the available modes.
 //
-//	  @Description	Order: Switch -> Rename -> Create -> Switch
+//	  @Description	Order: Switch -> Rename -> Create -> Marks -> Servers -> Switch
+//	  @Description	Servers is skipped when only one tmux server is found,
+//	  @Description	Rename when there is no session to rename
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *manager) cycleMode() {
-	sessions, _ := tmux.ListSessions()
+	sessions := m.listSessions()
 	switch m.mode.(type) {
 	case *modes.SwitchMode:
 		if len(sessions) > 0 {
 			m.mode = modes.NewRenameMode("")
+		} else {
+			m.mode = modes.NewCreateMode(m.dirs)
 		}
 	case *modes.RenameMode:
 		m.mode = modes.NewCreateMode(m.dirs)
 	case *modes.CreateMode:
-		m.mode = modes.NewSwitchMode(sessions)
+		m.mode = modes.NewMarksMode()
+	case *modes.MarksMode:
+		if len(tmux.DiscoverServers()) > 1 {
+			m.mode = modes.NewServersMode()
+		} else {
+			m.mode = modes.NewSwitchMode(sessions)
+		}
 	default:
 		m.mode = modes.NewSwitchMode(sessions)
 	}
@@ -231,12 +510,26 @@ func (m *manager) renderHelpOverlay() string {
 		Width(m.totalContentWidth()).
 		Height(styles.CurrentTheme.ContainerHeight).
 		Render(strings.Repeat("\n", styles.CurrentTheme.ContainerHeight))
-	help := RenderHelpDialog(m.totalContentWidth())
+	help := RenderHelpDialog(m.totalContentWidth(), m.helpGroups(), m.helpFilter)
 	dimmed := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, dim)
 	overlayed := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, help)
 	return dimmed + "\n" + overlayed
 }
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			helpGroups collects the shortcut sections shown in the help dialog.
+//
+//	@Return	    []model.ShortcutGroup	Global shortcuts followed by the current mode's
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) helpGroups() []model.ShortcutGroup {
+	return []model.ShortcutGroup{
+		{Title: "GLOBAL", Shortcuts: model.GlobalShortcuts},
+		{Title: m.modeLabel() + " MODE", Shortcuts: m.mode.GetShortcuts()},
+	}
+}
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	@Brief			renderHeader renders the application header.
@@ -260,7 +553,7 @@ func (m *manager) renderHeader() string {
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *manager) renderTitle() string {
 	style := lipgloss.NewStyle().Bold(true).Foreground(styles.CurrentTheme.AccentColor)
-	left := lipgloss.NewStyle().Width(styles.CurrentTheme.LeftPanelWidth).Render(style.Render("󱎫 TSM"))
+	left := lipgloss.NewStyle().Width(styles.CurrentTheme.LeftPanelWidth).Render(style.Render(styles.CurrentIcons.Title + " TSM"))
 	return left
 }
 
@@ -272,16 +565,33 @@ func (m *manager) renderTitle() string {
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *manager) renderModeIndicator() string {
-	modeText := m.mode.GetIcon() + " " + m.modeLabel()
-	right := lipgloss.NewStyle().
-		Align(lipgloss.Right).
+	modeText := lipgloss.NewStyle().
 		Foreground(styles.CurrentTheme.HighlightColor).
 		Bold(true).
+		Render(m.mode.GetIcon() + " " + m.modeLabel())
+	right := lipgloss.NewStyle().
+		Align(lipgloss.Right).
 		Width(styles.CurrentTheme.RightPanelWidth).
-		Render(modeText)
+		Render(m.renderSortLabel() + modeText)
 	return right
 }
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			renderSortLabel renders the sort order of modes implementing modes.Sorter.
+//
+//	@Return		string	Dimmed sort icon and order followed by a gap, or nothing
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) renderSortLabel() string {
+	s, ok := m.mode.(modes.Sorter)
+	if !ok {
+		return ""
+	}
+	label := styles.CurrentIcons.Sort + " " + s.SortLabel()
+	return lipgloss.NewStyle().Foreground(styles.CurrentTheme.SecondaryColor).Render(label) + "  "
+}
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	@Brief			renderBody renders the main content body.
@@ -293,16 +603,46 @@ func (m *manager) renderBody() string {
 	return styles.CurrentTheme.ListStyle.Render(m.mode.View())
 }
 
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			renderBanner renders the no-session banner below the header.
+//
+//	@Return	    string	Banner centered in the content width
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) renderBanner() string {
+	text := lipgloss.NewStyle().Foreground(styles.CurrentTheme.AccentColor).Render(styles.CurrentIcons.Text(m.banner))
+	return lipgloss.PlaceHorizontal(m.totalContentWidth(), lipgloss.Center, text)
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			bannerHeight returns the number of lines taken by the banner.
+//
+//	@Return	    int		0 without a banner
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) bannerHeight() int {
+	if m.banner == "" {
+		return 0
+	}
+	return lipgloss.Height(m.renderBanner())
+}
+
 // ///////////////////////////////////////////////////////////////////////////////////////////
 //
 //	@Brief			renderFooter renders the application footer.
 //
-//	@Return	    string	Footer with help text from current mode
+//	@Return	    string	Footer with help text from current mode, or the startup notice
 //
 // ///////////////////////////////////////////////////////////////////////////////////////////
 func (m *manager) renderFooter() string {
-	text := m.mode.GetFooterText()
+	text := styles.CurrentIcons.Text(m.mode.GetFooterText())
 	styledText := lipgloss.NewStyle().Foreground(styles.CurrentTheme.SecondaryColor).Render(text)
+	if m.notice != "" {
+		notice := styles.CurrentIcons.Text(m.notice)
+		styledText = lipgloss.NewStyle().Foreground(styles.CurrentTheme.AccentColor).Render(notice)
+	}
 	return styles.CurrentTheme.FooterStyle.Render(
 		lipgloss.PlaceHorizontal(m.totalContentWidth(), lipgloss.Center, styledText),
 	)
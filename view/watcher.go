@@ -0,0 +1,161 @@
+package view
+
+import (
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	modes "github.com/jkeresman01/tsm/modes"
+	"github.com/jkeresman01/tsm/tmux"
+)
+
+// watcherRetry is the delay before a failed or closed watcher is started again.
+const watcherRetry = 2 * time.Second
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			watcherStartedMsg carries the result of starting the session watcher.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type watcherStartedMsg struct {
+	watcher *tmux.Watcher
+	server  tmux.Server
+	err     error
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			watcherEventMsg carries a notification of a watcher.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type watcherEventMsg struct {
+	watcher *tmux.Watcher
+	event   tmux.Event
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	@Brief			watcherClosedMsg reports that the control-mode client exited.
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+type watcherClosedMsg struct {
+	watcher *tmux.Watcher
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			startWatcher attaches a control-mode client to the selected server.
+//
+//		@Return			tea.Msg		watcherStartedMsg
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func startWatcher() tea.Msg {
+	server := tmux.CurrentServer()
+	w, err := tmux.Watch()
+	return watcherStartedMsg{watcher: w, server: server, err: err}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			retryWatcher starts the watcher again after watcherRetry.
+//
+//		@Description	Covers servers without sessions, which control mode cannot attach to
+//
+//		@Return			tea.Cmd		Delayed startWatcher
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func retryWatcher() tea.Cmd {
+	return tea.Tick(watcherRetry, func(time.Time) tea.Msg { return startWatcher() })
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			waitForEvent delivers the next notification of a watcher.
+//
+//		@Param			w		*tmux.Watcher	Running watcher
+//
+//		@Return			tea.Cmd		Command returning a watcherEventMsg or watcherClosedMsg
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func waitForEvent(w *tmux.Watcher) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-w.Events()
+		if !ok {
+			return watcherClosedMsg{watcher: w}
+		}
+		return watcherEventMsg{watcher: w, event: ev}
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			refreshSessions lists the sessions after a notification.
+//
+//		@Param			ev		tmux.Event	Notification that triggered the refresh
+//
+//		@Return			tea.Cmd		Command returning a modes.SessionsChangedMsg
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func refreshSessions(ev tmux.Event) tea.Cmd {
+	return func() tea.Msg {
+		sessions, _ := tmux.ListSessions()
+		return modes.SessionsChangedMsg{Sessions: sessions, Event: ev}
+	}
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			handleWatcher processes the session watcher's messages.
+//
+//		@Description	Notifications become a modes.SessionsChangedMsg for the active mode,
+//		@Description	a watcher that failed or exited is started again after a delay unless
+//		@Description	tmux is missing or too old for control mode
+//
+//		@Param			msg		tea.Msg		watcherStartedMsg, watcherEventMsg or watcherClosedMsg
+//
+//		@Return			tea.Cmd		Command to execute
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) handleWatcher(msg tea.Msg) tea.Cmd {
+	switch t := msg.(type) {
+	case watcherStartedMsg:
+		if errors.Is(t.err, tmux.ErrUnsupported) || errors.Is(t.err, tmux.ErrNotInstalled) {
+			return nil
+		}
+		if t.err != nil {
+			return retryWatcher()
+		}
+		m.watcher, m.watched = t.watcher, t.server
+		return tea.Batch(waitForEvent(t.watcher), refreshSessions(tmux.Event{Name: "sessions-changed"}))
+	case watcherEventMsg:
+		if t.watcher != m.watcher {
+			return nil
+		}
+		return tea.Batch(waitForEvent(t.watcher), refreshSessions(t.event))
+	case watcherClosedMsg:
+		if t.watcher != m.watcher {
+			return nil
+		}
+		m.watcher = nil
+		return retryWatcher()
+	}
+	return nil
+}
+
+// ///////////////////////////////////////////////////////////////////////////////////////////
+//
+//	 @Brief			followServer moves the watcher when another tmux server is selected.
+//
+//		@Description	Closing the watcher yields a watcherClosedMsg, which restarts it on
+//		@Description	the newly selected server. The cached sessions belong to the old server,
+//		@Description	so they are dropped and listSessions asks tmux until the new watcher reports
+//
+// ///////////////////////////////////////////////////////////////////////////////////////////
+func (m *manager) followServer() {
+	if m.watcher != nil && m.watched != tmux.CurrentServer() {
+		m.watcher.Close()
+		m.watched = tmux.CurrentServer()
+		m.sessions = nil
+	}
+}